@@ -1,29 +1,246 @@
 package gomatch
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 var errNotArray = errors.New("expected array")
 
 // An ArrayMatcher matches []interface{}.
+//
+// Supported expanders:
+//
+//  @array@.unique()
+//  @array@.uniqueBy("id")
+//  @array@.sortedBy("created_at")
+//  @array@.sortedBy("created_at", "desc")
+//  @array@.length(3)
+//  @array@.length($.total_count)
+//  @array@.every({"id": "@number@"})
+//
+// The every expander is handled by JSONMatcher, which matches every element
+// with its nested pattern, so it has to be the last one.
 type ArrayMatcher struct {
 	pattern string
 }
 
+var arrayExpanders = expanderSet{
+	"unique":   arrayUnique,
+	"uniqueBy": arrayUniqueBy,
+	"sortedBy": arraySortedBy,
+	"length":   arrayLength,
+}
+
 // CanMatch returns true if pattern p can be handled
 func (m *ArrayMatcher) CanMatch(p interface{}) bool {
-	return isPattern(p, m.pattern)
+	_, ok := arrayExpanders.build(p, m.pattern)
+	return ok
 }
 
 // Match performs value matching against given pattern.
 func (m *ArrayMatcher) Match(p, v interface{}) (bool, error) {
-	_, ok := v.([]interface{})
-	if ok {
-		return ok, nil
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *ArrayMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return arrayExpanders.compile(p, m.pattern, validateArray)
+}
+
+func (m *ArrayMatcher) patternExpanders() expanderSet {
+	return arrayExpanders
+}
+
+func validateArray(v interface{}) error {
+	if _, ok := v.([]interface{}); !ok {
+		return errNotArray
 	}
-	return ok, errNotArray
+	return nil
 }
 
 // NewArrayMatcher creates ArrayMatcher.
 func NewArrayMatcher(pattern string) *ArrayMatcher {
 	return &ArrayMatcher{pattern}
 }
+
+func arrayUnique(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		return checkUnique(v.([]interface{}), func(e interface{}) (interface{}, error) {
+			return e, nil
+		}, "expected unique elements")
+	}, nil
+}
+
+func arrayUniqueBy(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	if !args[0].isString() {
+		return nil, errInvalidArgs
+	}
+	key := args[0].String()
+	return func(v interface{}) error {
+		return checkUnique(v.([]interface{}), func(e interface{}) (interface{}, error) {
+			return elementKey(e, key)
+		}, fmt.Sprintf(`expected elements with unique "%s"`, key))
+	}, nil
+}
+
+// checkUnique reports indexes of elements having the same value returned by fn.
+func checkUnique(elements []interface{}, fn func(e interface{}) (interface{}, error), msg string) error {
+	seen := make(map[string]int, len(elements))
+	var duplicates []string
+	for i, e := range elements {
+		v, err := fn(e)
+		if err != nil {
+			return fmt.Errorf("%s, element [%d] %s", msg, i, err.Error())
+		}
+		k := canonicalValue(v)
+		if first, ok := seen[k]; ok {
+			duplicates = append(duplicates, fmt.Sprintf("[%d] equals [%d]", i, first))
+			continue
+		}
+		seen[k] = i
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%s, element %s", msg, strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+func arrayLength(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	n, err := intArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		if l := len(v.([]interface{})); l != n {
+			return fmt.Errorf("expected array of length %d, got %d", n, l)
+		}
+		return nil
+	}, nil
+}
+
+func arraySortedBy(args []patternArg) (func(v interface{}) error, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, errInvalidArgs
+	}
+	if !args[0].isString() {
+		return nil, errInvalidArgs
+	}
+	key, order := args[0].String(), "asc"
+	if len(args) == 2 {
+		order = args[1].String()
+		if !args[1].isString() || order != "asc" && order != "desc" {
+			return nil, errInvalidArgs
+		}
+	}
+	msg := fmt.Sprintf(`expected elements sorted by "%s" %s`, key, order)
+	return func(v interface{}) error {
+		elements := v.([]interface{})
+		var prev interface{}
+		var unordered []string
+		for i, e := range elements {
+			k, err := elementKey(e, key)
+			if err != nil {
+				return fmt.Errorf("%s, element [%d] %s", msg, i, err.Error())
+			}
+			if i > 0 {
+				c, ok := compareValues(prev, k)
+				if !ok {
+					return fmt.Errorf("%s, element [%d] can't be compared with [%d]", msg, i, i-1)
+				}
+				if order == "asc" && c > 0 || order == "desc" && c < 0 {
+					unordered = append(unordered, fmt.Sprintf("[%d]", i))
+				}
+			}
+			prev = k
+		}
+		switch len(unordered) {
+		case 0:
+			return nil
+		case 1:
+			return fmt.Errorf("%s, element %s is out of order", msg, unordered[0])
+		}
+		return fmt.Errorf("%s, elements %s are out of order", msg, strings.Join(unordered, ", "))
+	}, nil
+}
+
+// elementKey returns a value of key of an array element being an object.
+func elementKey(e interface{}, key string) (interface{}, error) {
+	m, ok := e.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("is not an object")
+	}
+	v, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf(`has no key "%s"`, key)
+	}
+	return v, nil
+}
+
+// compareValues compares two numbers or two strings.
+// It returns false if values can't be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	if isNumber(a) && isNumber(b) {
+		da, okA := parseDecimal(toNumber(a).String())
+		db, okB := parseDecimal(toNumber(b).String())
+		if okA && okB {
+			return da.cmp(db), true
+		}
+		return 0, false
+	}
+	sa, okA := a.(string)
+	sb, okB := b.(string)
+	if okA && okB {
+		return strings.Compare(sa, sb), true
+	}
+	return 0, false
+}
+
+// canonicalValue returns a string identifying a value decoded from JSON,
+// equal numbers written differently, e.g. 1 and 1.0, have the same canonical value.
+func canonicalValue(v interface{}) string {
+	var b strings.Builder
+	writeCanonicalValue(&b, v)
+	return b.String()
+}
+
+func writeCanonicalValue(b *strings.Builder, v interface{}) {
+	switch t := v.(type) {
+	case []interface{}:
+		b.WriteByte('[')
+		for _, e := range t {
+			writeCanonicalValue(b, e)
+			b.WriteByte(',')
+		}
+		b.WriteByte(']')
+	case map[string]interface{}:
+		b.WriteByte('{')
+		for _, k := range sortedKeys(t) {
+			b.WriteString(strconv.Quote(k))
+			b.WriteByte(':')
+			writeCanonicalValue(b, t[k])
+			b.WriteByte(',')
+		}
+		b.WriteByte('}')
+	case string:
+		b.WriteString(strconv.Quote(t))
+	default:
+		if isNumber(v) {
+			if d, ok := parseDecimal(toNumber(v).String()); ok {
+				fmt.Fprintf(b, "n%t.%se%d", d.neg, d.digits, d.exp)
+				return
+			}
+		}
+		fmt.Fprintf(b, "%v", v)
+	}
+}
@@ -1,6 +1,7 @@
 package gomatch
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,60 @@ func TestArrayMatcher(t *testing.T) {
 		}
 	}
 }
+
+func arrayOf(ids ...interface{}) []interface{} {
+	elements := make([]interface{}, len(ids))
+	for i, id := range ids {
+		elements[i] = map[string]interface{}{"id": id}
+	}
+	return elements
+}
+
+var arrayExpanderTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match unique elements", "@array@.unique()", []interface{}{json.Number("1"), "1", arrayOf(1)}, true, ""},
+	{"Should report duplicated elements", "@array@.unique()", []interface{}{json.Number("1"), "a", json.Number("1.0"), "a"}, false, "expected unique elements, element [2] equals [0], [3] equals [1]"},
+	{"Should compare objects regardless of keys order", "@array@.unique()", []interface{}{map[string]interface{}{"a": 1., "b": 2.}, map[string]interface{}{"b": 2., "a": 1.}}, false, "expected unique elements, element [1] equals [0]"},
+	{"Should match elements with unique key", `@array@.uniqueBy("id")`, arrayOf(1., 2., 3.), true, ""},
+	{"Should report elements with duplicated key", `@array@.uniqueBy("id")`, arrayOf(1., 2., 1.), false, `expected elements with unique "id", element [2] equals [0]`},
+	{"Should report element without key", `@array@.uniqueBy("id")`, []interface{}{map[string]interface{}{}}, false, `expected elements with unique "id", element [0] has no key "id"`},
+	{"Should report element not being an object", `@array@.uniqueBy("id")`, []interface{}{1.}, false, `expected elements with unique "id", element [0] is not an object`},
+	{"Should match sorted elements", `@array@.sortedBy("id")`, arrayOf(json.Number("1"), json.Number("1.0"), json.Number("2")), true, ""},
+	{"Should match elements sorted descending", `@array@.sortedBy("id", "desc")`, arrayOf("2020-02-01", "2020-01-01"), true, ""},
+	{"Should report element out of order", `@array@.sortedBy("id", "asc")`, arrayOf(1., 3., 2.), false, `expected elements sorted by "id" asc, element [2] is out of order`},
+	{"Should report all elements out of order", `@array@.sortedBy("id", "desc")`, arrayOf(5., 4., 6., 3., 2., 7.), false, `expected elements sorted by "id" desc, elements [2], [5] are out of order`},
+	{"Should report elements which can't be compared", `@array@.sortedBy("id")`, arrayOf(1., "2"), false, `expected elements sorted by "id" asc, element [1] can't be compared with [0]`},
+	{"Should match empty array", `@array@.unique().sortedBy("id")`, []interface{}{}, true, ""},
+	{"Should match array of given length", `@array@.length(2)`, arrayOf(1., 2.), true, ""},
+	{"Should report array of different length", `@array@.length(3)`, arrayOf(1., 2.), false, "expected array of length 3, got 2"},
+}
+
+func TestArrayMatcherExpanders(t *testing.T) {
+	m := NewArrayMatcher("@array@")
+	for _, tt := range arrayExpanderTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestArrayMatcherInvalidExpanders(t *testing.T) {
+	m := NewArrayMatcher("@array@")
+	for _, p := range []string{"@array@.unique(1)", "@array@.uniqueBy(1)", `@array@.sortedBy()`, `@array@.sortedBy("id", "up")`, `@array@.sortedBy("id", "asc", 1)`, "@array@.length()", "@array@.length(-1)", `@array@.length("2")`} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
@@ -0,0 +1,142 @@
+package gomatch
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const patternBase64 = "base64"
+
+// A base64Pattern is a "@base64@" pattern of a compiled pattern. It matches base64 strings
+// and optionally matches decoded JSON with a nested pattern:
+//
+//  "@base64@"
+//  "@base64@.url().raw()"          // URL-safe alphabet without padding
+//  "@base64@.json({\"id\": \"@number@\"})"
+//
+// Expanders std() and url() restrict the alphabet, padded() and raw() restrict padding,
+// by default any of them is accepted.
+type base64Pattern struct {
+	encodings []*base64.Encoding
+	desc      string
+	expected  interface{}
+	json      bool
+}
+
+func (c *config) parseBase64Pattern(s string, parsed *parsedPattern) (nestedPattern, error) {
+	if parsed.hasArgs {
+		return nil, fmt.Errorf(`invalid base64 pattern "%s": unexpected arguments`, s)
+	}
+	std, url, padded, raw := false, false, false, false
+	p := &base64Pattern{}
+	for _, e := range parsed.expanders {
+		args, err := parseArgs(e.args)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid base64 pattern "%s": %s`, s, err.Error())
+		}
+		if e.name == "json" {
+			if len(args) != 1 {
+				return nil, fmt.Errorf(`invalid base64 pattern "%s": expected a single JSON argument of json()`, s)
+			}
+			if p.expected, err = c.decodeNestedPattern(s, args[0]); err != nil {
+				return nil, err
+			}
+			p.json = true
+			continue
+		}
+		if len(args) > 0 {
+			return nil, fmt.Errorf(`invalid base64 pattern "%s": unexpected arguments of %s()`, s, e.name)
+		}
+		switch e.name {
+		case "std":
+			std = true
+		case "url":
+			url = true
+		case "padded":
+			padded = true
+		case "raw":
+			raw = true
+		default:
+			return nil, fmt.Errorf(`invalid base64 pattern "%s": unknown expander %s()`, s, e.name)
+		}
+	}
+	if std && url || padded && raw {
+		return nil, fmt.Errorf(`invalid base64 pattern "%s": conflicting expanders`, s)
+	}
+	var desc []string
+	if raw != padded {
+		if raw {
+			desc = append(desc, "unpadded")
+		} else {
+			desc = append(desc, "padded")
+		}
+	}
+	if url != std {
+		if url {
+			desc = append(desc, "URL-safe")
+		} else {
+			desc = append(desc, "standard")
+		}
+	}
+	p.desc = strings.Join(append(desc, "base64"), " ")
+	for _, enc := range []struct {
+		e        *base64.Encoding
+		url, raw bool
+	}{
+		{base64.StdEncoding, false, false},
+		{base64.URLEncoding, true, false},
+		{base64.RawStdEncoding, false, true},
+		{base64.RawURLEncoding, true, true},
+	} {
+		if (url == std || enc.url == url) && (raw == padded || enc.raw == raw) {
+			p.encodings = append(p.encodings, enc.e.Strict())
+		}
+	}
+	return p, nil
+}
+
+func (b *base64Pattern) compile(p *CompiledPattern, path []interface{}) error {
+	if !b.json {
+		return nil
+	}
+	var err error
+	b.expected, err = p.compile(b.expected, path)
+	return err
+}
+
+func (b *base64Pattern) match(s *matchState, v interface{}) {
+	str, ok := v.(string)
+	if !ok {
+		s.fail(fmt.Errorf("expected %s string", b.desc))
+		return
+	}
+	data, ok := b.decode(str)
+	if !ok {
+		s.fail(fmt.Errorf("expected %s string", b.desc))
+		return
+	}
+	if !b.json {
+		return
+	}
+	actual, err := decodeJSON(data, s.p.useNumber())
+	if err != nil {
+		s.fail(fmt.Errorf("expected %s encoded JSON", b.desc))
+		return
+	}
+	s.deepMatch(b.expected, actual)
+}
+
+// decode decodes s with the first matching encoding.
+func (b *base64Pattern) decode(s string) ([]byte, bool) {
+	// decoders ignore new lines, but they are not allowed in JSON values
+	if strings.ContainsAny(s, "\r\n") {
+		return nil, false
+	}
+	for _, e := range b.encodings {
+		if data, err := e.DecodeString(s); err == nil {
+			return data, true
+		}
+	}
+	return nil, false
+}
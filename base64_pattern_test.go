@@ -0,0 +1,66 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var base64PatternTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match standard base64", `"@base64@"`, `"+/8="`, true, ""},
+	{"Should match URL-safe base64", `"@base64@"`, `"-_8="`, true, ""},
+	{"Should match unpadded base64", `"@base64@"`, `"-_8"`, true, ""},
+	{"Should match empty string", `"@base64@"`, `""`, true, ""},
+	{"Should not match invalid base64", `"@base64@"`, `"a"`, false, "expected base64 string"},
+	{"Should not match not canonical base64", `"@base64@"`, `"-_9="`, false, "expected base64 string"},
+	{"Should not match new lines", `"@base64@"`, `"+/\n8="`, false, "expected base64 string"},
+	{"Should not match number", `"@base64@"`, `1`, false, "expected base64 string"},
+	{"Should match URL-safe alphabet", `"@base64@.url()"`, `"-_8"`, true, ""},
+	{"Should not match standard alphabet", `"@base64@.url()"`, `"+/8="`, false, "expected URL-safe base64 string"},
+	{"Should not match URL-safe alphabet", `"@base64@.std()"`, `"-_8="`, false, "expected standard base64 string"},
+	{"Should not match padding", `"@base64@.raw().url()"`, `"-_8="`, false, "expected unpadded URL-safe base64 string"},
+	{"Should not match missing padding", `"@base64@.padded()"`, `"+/8"`, false, "expected padded base64 string"},
+	{"Should match encoded JSON", `{"data": "@base64@.json({\"id\": \"@number@\"})"}`, `{"data": "eyJpZCI6NX0="}`, true, ""},
+	{"Should report path inside encoded JSON", `{"data": "@base64@.json({\"id\": \"@string@\"})"}`, `{"data": "eyJpZCI6NX0="}`, false, "expected string at path: data.id"},
+	{"Should not match encoded bytes which are not JSON", `{"data": "@base64@.std().json(\"@wildcard@\")"}`, `{"data": "+/8="}`, false, "expected standard base64 encoded JSON at path: data"},
+}
+
+func TestBase64Pattern(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range base64PatternTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestBase64PatternInvalid(t *testing.T) {
+	tests := []struct {
+		p      string
+		errMsg string
+	}{
+		{`"@base64@(1)"`, `invalid JSON pattern: invalid base64 pattern "@base64@(1)": unexpected arguments`},
+		{`"@base64@.std().url()"`, `invalid JSON pattern: invalid base64 pattern "@base64@.std().url()": conflicting expanders`},
+		{`"@base64@.raw(1)"`, `invalid JSON pattern: invalid base64 pattern "@base64@.raw(1)": unexpected arguments of raw()`},
+		{`"@base64@.json()"`, `invalid JSON pattern: invalid base64 pattern "@base64@.json()": expected a single JSON argument of json()`},
+		{`"@base64@.hex()"`, `invalid JSON pattern: invalid base64 pattern "@base64@.hex()": unknown expander hex()`},
+	}
+	for _, tt := range tests {
+		_, err := NewDefaultJSONMatcher().Compile(tt.p)
+
+		assert.EqualError(t, err, tt.errMsg)
+	}
+}
@@ -0,0 +1,35 @@
+package gomatch
+
+import (
+	"errors"
+	"net"
+)
+
+var errNotCIDR = errors.New("expected CIDR notation")
+
+// A CIDRMatcher matches IP networks in CIDR notation, e.g. "192.168.0.0/16" or "2001:db8::/32".
+type CIDRMatcher struct {
+	pattern string
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *CIDRMatcher) CanMatch(p interface{}) bool {
+	return isPattern(p, m.pattern)
+}
+
+// Match performs value matching against given pattern.
+func (m *CIDRMatcher) Match(p, v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, errNotCIDR
+	}
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return false, errNotCIDR
+	}
+	return true, nil
+}
+
+// NewCIDRMatcher creates CIDRMatcher.
+func NewCIDRMatcher(pattern string) *CIDRMatcher {
+	return &CIDRMatcher{pattern}
+}
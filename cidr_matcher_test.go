@@ -0,0 +1,41 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var cidrMatcherTests = []struct {
+	desc   string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match IPv4 network", "192.168.0.0/16", true, ""},
+	{"Should match IPv6 network", "2001:db8::/32", true, ""},
+	{"Should not match IP address", "192.168.0.1", false, "expected CIDR notation"},
+	{"Should not match invalid prefix", "192.168.0.0/33", false, "expected CIDR notation"},
+	{"Should not match number", 1., false, "expected CIDR notation"},
+}
+
+func TestCIDRMatcher(t *testing.T) {
+	pattern := "@pattern@"
+
+	for _, tt := range cidrMatcherTests {
+		m := NewCIDRMatcher(pattern)
+		assert.True(t, m.CanMatch(pattern), "expected to support pattern")
+
+		t.Logf(tt.desc)
+
+		ok, err := m.Match(pattern, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package gomatch
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Clock returns the current time.
+// Matchers checking timestamps relative to now, e.g. "@ulid@.within(\"1h\")",
+// use time.Now unless another clock is set, which makes them testable.
+type Clock func() time.Time
+
+// A timestampDecoder returns a timestamp embedded in a value, e.g. in an ULID.
+type timestampDecoder func(v interface{}) (time.Time, error)
+
+// timestampExpanders returns expanders checking a timestamp embedded in a value:
+//
+//  .after("2020-01-01T00:00:00Z")
+//  .before("2030-01-01T00:00:00Z")
+//  .within("24h")    // at most 24 hours from now, in the past or in the future
+//
+// The clock is read on every match, so it may be set after expanders are created.
+func timestampExpanders(decode timestampDecoder, clock *Clock) expanderSet {
+	return expanderSet{
+		"after":  timestampCompare("after", decode, func(ts, t time.Time) bool { return ts.After(t) }),
+		"before": timestampCompare("before", decode, func(ts, t time.Time) bool { return ts.Before(t) }),
+		"within": func(args []patternArg) (func(v interface{}) error, error) {
+			if err := argsCount(args, 1); err != nil {
+				return nil, err
+			}
+			if !args[0].isString() {
+				return nil, errNotString
+			}
+			d, err := time.ParseDuration(args[0].String())
+			if err != nil || d < 0 {
+				return nil, errInvalidArgs
+			}
+			return func(v interface{}) error {
+				ts, err := decode(v)
+				if err != nil {
+					return err
+				}
+				now := (*clock)()
+				if ts.Before(now.Add(-d)) || ts.After(now.Add(d)) {
+					return fmt.Errorf("expected timestamp within %s from now, got %s", d, ts.UTC().Format(time.RFC3339Nano))
+				}
+				return nil
+			}, nil
+		},
+	}
+}
+
+func timestampCompare(desc string, decode timestampDecoder, fn func(ts, t time.Time) bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 1); err != nil {
+			return nil, err
+		}
+		if !args[0].isString() {
+			return nil, errNotString
+		}
+		t, err := time.Parse(time.RFC3339Nano, args[0].String())
+		if err != nil {
+			return nil, errInvalidArgs
+		}
+		return func(v interface{}) error {
+			ts, err := decode(v)
+			if err != nil {
+				return err
+			}
+			if !fn(ts, t) {
+				return fmt.Errorf("expected timestamp %s %s, got %s", desc, args[0].String(), ts.UTC().Format(time.RFC3339Nano))
+			}
+			return nil
+		}, nil
+	}
+}
+
+// A timestampIDMatcher matches IDs having an embedded timestamp, e.g. ULIDs,
+// and supports expanders checking the timestamp, see timestampExpanders.
+// It is embedded by matchers of such IDs, which provide a decoder of the timestamp.
+type timestampIDMatcher struct {
+	pattern   string
+	decode    timestampDecoder
+	clock     Clock
+	expanders expanderSet
+	// wrap returns the embedding matcher of a copy of the matcher
+	wrap func(m *timestampIDMatcher) ValueMatcher
+}
+
+func newTimestampIDMatcher(pattern string, decode timestampDecoder, wrap func(m *timestampIDMatcher) ValueMatcher) *timestampIDMatcher {
+	m := &timestampIDMatcher{pattern: pattern, decode: decode, clock: time.Now, wrap: wrap}
+	m.expanders = timestampExpanders(decode, &m.clock)
+	return m
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *timestampIDMatcher) CanMatch(p interface{}) bool {
+	_, ok := m.expanders.build(p, m.pattern)
+	return ok
+}
+
+// Match performs value matching against given pattern.
+func (m *timestampIDMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *timestampIDMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return m.expanders.compile(p, m.pattern, func(v interface{}) error {
+		_, err := m.decode(v)
+		return err
+	})
+}
+
+func (m *timestampIDMatcher) patternExpanders() expanderSet {
+	return m.expanders
+}
+
+// SetClock sets a clock used by the "within" expander. It panics if c is nil.
+// It must not be called while the matcher is used. JSONMatcher created with WithClock
+// uses its own copy of the matcher instead.
+func (m *timestampIDMatcher) SetClock(c Clock) {
+	m.clock = mustClock(c)
+}
+
+func (m *timestampIDMatcher) withClock(c Clock) ValueMatcher {
+	n := newTimestampIDMatcher(m.pattern, m.decode, m.wrap)
+	n.SetClock(c)
+	return m.wrap(n)
+}
+
+// A clockUser is a value matcher using a clock.
+type clockUser interface {
+	// withClock returns a copy of the matcher using clock c
+	withClock(c Clock) ValueMatcher
+}
+
+// withClock returns value matcher m, or a chain of matchers, where matchers using a clock
+// are replaced with their copies using clock c, so matchers of the caller are not changed.
+func withClock(m ValueMatcher, c Clock) ValueMatcher {
+	switch t := m.(type) {
+	case *ChainMatcher:
+		matchers := make([]ValueMatcher, len(t.matchers))
+		for i, m := range t.matchers {
+			matchers[i] = withClock(m, c)
+		}
+		return NewChainMatcher(matchers)
+	case clockUser:
+		return t.withClock(c)
+	}
+	return m
+}
+
+// mustClock returns clock c. It panics if c is nil.
+func mustClock(c Clock) Clock {
+	if c == nil {
+		panic("gomatch: nil clock")
+	}
+	return c
+}
@@ -0,0 +1,58 @@
+package gomatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithClock(t *testing.T) {
+	now := func() time.Time { return time.Date(2016, 7, 31, 0, 0, 0, 0, time.UTC) }
+	m := NewDefaultJSONMatcher(WithClock(now))
+
+	ok, err := m.Match(`{"id": "@ulid@.within(\"1h\")"}`, `{"id": "01ARZ3NDEKTSV4RRFFQ69G5FAV"}`)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, err = m.Match(`{"id": "@objectid@.within(\"1h\")"}`, `{"id": "507f1f77bcf86cd799439011"}`)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "expected timestamp within 1h0m0s from now, got 2012-10-17T21:13:27Z at path: id")
+}
+
+func TestWithClockDoesNotChangeMatchers(t *testing.T) {
+	ulid := NewULIDMatcher(patternULID)
+	m1 := New(WithMatchers(ulid), WithClock(func() time.Time { return time.Date(2016, 7, 31, 0, 0, 0, 0, time.UTC) }))
+	m2 := New(WithClock(func() time.Time { return time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) }), WithMatchers(ulid))
+	p, v := `"@ulid@.within(\"1h\")"`, `"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	ok, err := m1.Match(p, v)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, err = m2.Match(p, v)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "expected timestamp within 1h0m0s from now, got 2016-07-30T23:54:10.259Z")
+
+	ok, err = ulid.Match(`@ulid@.within("1h")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.False(t, ok, "matcher given to WithMatchers should use time.Now")
+	assert.Contains(t, err.Error(), "expected timestamp within 1h0m0s from now")
+}
+
+func TestWithClockCopiesMatchers(t *testing.T) {
+	now := func() time.Time { return time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC) }
+	snowflake := NewSnowflakeMatcherWithEpoch(patternSnowflake, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	c := withClock(snowflake, now)
+
+	assert.IsType(t, &SnowflakeMatcher{}, c)
+	assert.False(t, c == ValueMatcher(snowflake), "matcher should be copied")
+	ok, err := c.Match(`@snowflake@.within("1s")`, "4194304")
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestNilClock(t *testing.T) {
+	assert.Panics(t, func() { WithClock(nil) })
+	assert.Panics(t, func() { NewULIDMatcher(patternULID).SetClock(nil) })
+}
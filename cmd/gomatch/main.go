@@ -0,0 +1,85 @@
+// Command gomatch provides command line tools for gomatch JSON patterns.
+//
+// Usage:
+//
+//	gomatch lint [FILE...]
+//
+// The lint subcommand checks JSON pattern files for syntax errors and suspicious
+// constructs like unknown patterns or duplicate keys. It reads standard input
+// when no files are given. It exits with status 1 when any issue was found.
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/jfilipczyk/gomatch"
+)
+
+const usage = `usage: gomatch <command> [arguments]
+
+commands:
+  lint [FILE...]  check JSON patterns for errors and suspicious constructs
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "lint":
+		return lint(args[1:], stdin, stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "gomatch: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+func lint(files []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	m := gomatch.NewDefaultJSONMatcher()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	status := 0
+	for _, file := range files {
+		data, err := readFile(file, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gomatch: %s\n", err.Error())
+			status = 1
+			continue
+		}
+		issues, err := m.Lint(string(data))
+		if err != nil {
+			if perr, ok := err.(*gomatch.PatternError); ok {
+				fmt.Fprintf(stdout, "%s:%d:%d: %s\n", file, perr.Line, perr.Column, perr.Msg)
+			} else {
+				fmt.Fprintf(stdout, "%s: %s\n", file, err.Error())
+			}
+			status = 1
+			continue
+		}
+		for _, issue := range issues {
+			fmt.Fprintf(stdout, "%s:%d:%d: %s", file, issue.Line, issue.Column, issue.Msg)
+			if issue.Path != "" {
+				fmt.Fprintf(stdout, " at path: %s", issue.Path)
+			}
+			fmt.Fprintln(stdout)
+			status = 1
+		}
+	}
+	return status
+}
+
+func readFile(file string, stdin io.Reader) ([]byte, error) {
+	if file == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(file)
+}
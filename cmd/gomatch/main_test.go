@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomatch")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	ioutil.WriteFile(valid, []byte(`{"id": "@number@"}`), 0644)
+	ioutil.WriteFile(invalid, []byte("{\n  \"id\": \"@nubmer@\"\n}"), 0644)
+
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"lint", valid, invalid}, nil, &stdout, &stderr)
+
+	assert.Equal(t, 1, status)
+	assert.Equal(t, invalid+":2:9: unknown pattern \"@nubmer@\" will be compared as a string at path: id\n", stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestLintStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"lint"}, strings.NewReader(`{"id":}`), &stdout, &stderr)
+
+	assert.Equal(t, 1, status)
+	assert.Equal(t, "-:1:7: invalid character '}' looking for beginning of value\n", stdout.String())
+}
+
+func TestUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"foo"}, nil, &stdout, &stderr)
+
+	assert.Equal(t, 2, status)
+	assert.Contains(t, stderr.String(), `unknown command "foo"`)
+}
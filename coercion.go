@@ -0,0 +1,50 @@
+package gomatch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// coerce converts a string of actual JSON to a value of another type,
+// see WithTypeCoercion. It returns false if s can't be coerced.
+func (c *config) coerce(actual interface{}) (interface{}, bool) {
+	s, ok := actual.(string)
+	if !ok {
+		return nil, false
+	}
+	switch s {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	case "", "null":
+		return nil, true
+	}
+	// only strings being valid JSON numbers are coerced, e.g. not " 42" or "0x2a"
+	if strings.TrimSpace(s) != s {
+		return nil, false
+	}
+	if v, err := decodeJSON([]byte(s), c.useNumber()); err == nil && isNumber(v) {
+		return v, true
+	}
+	return nil, false
+}
+
+// isTypeMismatch returns true if a value pattern rejected a string because it expects
+// a value of another type. Only then the pattern is retried with a coerced value,
+// other mismatches, e.g. of "@string@.maxLength(1)", are reported as they are.
+func isTypeMismatch(err error) bool {
+	return err == errNotNumber || err == errNotBool
+}
+
+// matchCoerced matches a value coerced from string s noting coercion in reported mismatches.
+func (s *matchState) matchCoerced(expected, coerced interface{}, str string) {
+	s.coercedFrom = &str
+	defer func() { s.coercedFrom = nil }()
+	s.matchValue(expected, coerced)
+}
+
+// coercionNote returns a note appended to mismatches of coerced values.
+func coercionNote(str string) string {
+	return fmt.Sprintf(" (coerced from string %q)", str)
+}
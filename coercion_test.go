@@ -0,0 +1,64 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var typeCoercionTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match numeric string with number", `{"id": 42}`, `{"id": "42"}`, true, ""},
+	{"Should match numeric string written differently", `{"price": 12.5}`, `{"price": "1.25e1"}`, true, ""},
+	{"Should not match different number", `{"id": 42}`, `{"id": "43"}`, false, `values are not equal (coerced from string "43") at path: id`},
+	{"Should not coerce invalid number", `{"id": 42}`, `{"id": " 42"}`, false, "types are not equal at path: id"},
+	{"Should match boolean string", `{"active": true}`, `{"active": "true"}`, true, ""},
+	{"Should not match different boolean", `{"active": true}`, `{"active": "false"}`, false, `values are not equal (coerced from string "false") at path: active`},
+	{"Should not coerce boolean string to number", `{"active": 1}`, `{"active": "true"}`, false, "types are not equal at path: active"},
+	{"Should match empty string with null", `{"deleted_at": null}`, `{"deleted_at": ""}`, true, ""},
+	{"Should match null string with null", `{"deleted_at": null}`, `{"deleted_at": "null"}`, true, ""},
+	{"Should still match strings as strings", `{"id": "42"}`, `{"id": "42"}`, true, ""},
+	{"Should match numeric string with number pattern", `{"id": "@number@"}`, `{"id": "42"}`, true, ""},
+	{"Should match boolean string with bool pattern", `{"active": "@bool@"}`, `{"active": "false"}`, true, ""},
+	{"Should report pattern mismatch of coerced value", `{"id": "@number@.between(1, 10)"}`, `{"id": "42"}`, false, `expected number between 1 and 10 inclusive (coerced from string "42") at path: id`},
+	{"Should report pattern mismatch of not coerced value", `{"id": "@number@"}`, `{"id": "abc"}`, false, "expected number at path: id"},
+	{"Should report mismatch of string pattern without coercion", `{"a": "@string@.maxLength(1)"}`, `{"a": "42"}`, false, "expected string of length at most 1, got 2 at path: a"},
+	{"Should report mismatch of string pattern of empty string without coercion", `{"a": "@string@.minLength(3)"}`, `{"a": ""}`, false, "expected string of length at least 3, got 0 at path: a"},
+}
+
+func TestTypeCoercion(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithTypeCoercion())
+	for _, tt := range typeCoercionTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestTypeCoercionDisabled(t *testing.T) {
+	ok, err := NewDefaultJSONMatcher().Match(`{"id": 42}`, `{"id": "42"}`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "types are not equal at path: id")
+}
+
+func TestTypeCoercionLegacyNumbers(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithTypeCoercion(), WithNumberPrecision(NumberPrecisionFloat64))
+
+	ok, err := m.Match(`[1.5, "@number@"]`, `["1.5", "2"]`)
+
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
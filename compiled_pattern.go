@@ -0,0 +1,296 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// A PatternError describes a syntax error found in a JSON pattern.
+// Offset, Line and Column point to the place where the error was detected.
+type PatternError struct {
+	Msg    string
+	Offset int64
+	Line   int
+	Column int
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("%s: %s at line %d, column %d", errInvalidJSONPattern.Error(), e.Msg, e.Line, e.Column)
+}
+
+func newPatternError(data []byte, err error) error {
+	serr, ok := err.(*json.SyntaxError)
+	if !ok {
+		return fmt.Errorf("%s: %s", errInvalidJSONPattern.Error(), err.Error())
+	}
+	line, column := position(data, serr.Offset)
+	return &PatternError{serr.Error(), serr.Offset, line, column}
+}
+
+// position converts byte offset to 1-based line and column.
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line, column := 1, 1
+	for _, c := range data[:offset] {
+		if c == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	// the offset points just past the byte which caused the error
+	if column > 1 {
+		column--
+	}
+	return line, column
+}
+
+// A CompiledPattern is a JSON pattern parsed and resolved once by JSONMatcher.Compile.
+// It may be used to match many JSONs and is safe for concurrent use.
+type CompiledPattern struct {
+	config
+	expected interface{}
+	patterns map[interface{}]*valuePattern
+	// sourceOrders hold keys of objects of decoded pattern while it is compiled
+	sourceOrders keyOrders
+	// ignored hold selectors of ignored values, see WithIgnoredPaths
+	ignored []*pathSelector
+}
+
+// Compile parses expected JSON pattern and resolves all value patterns it contains
+// so they don't have to be resolved again on every match.
+//
+// Returned error is a *PatternError when the pattern is not a valid JSON.
+// An error is also returned when an expression pattern "@expr(...)@", a pattern with
+// nested patterns, e.g. "@json@(...)", or a reference in pattern arguments,
+// e.g. "@number@.equals($.total)", is not valid.
+func (m *JSONMatcher) Compile(expectedJSON string) (*CompiledPattern, error) {
+	return m.CompileBytes([]byte(expectedJSON))
+}
+
+// CompileBytes works like Compile but takes expected JSON pattern as a byte slice.
+func (m *JSONMatcher) CompileBytes(expectedJSON []byte) (*CompiledPattern, error) {
+	p := &CompiledPattern{
+		config:   m.config,
+		patterns: make(map[interface{}]*valuePattern),
+	}
+	if err := p.compileIgnoredPaths(); err != nil {
+		return nil, err
+	}
+	var expected interface{}
+	var err error
+	if m.strictKeyOrder || m.detectDuplicateKeys {
+		var keys *decodedKeys
+		if expected, keys, err = decodeOrderedJSON(expectedJSON, m.useNumber()); err != nil {
+			return nil, newPatternError(expectedJSON, err)
+		}
+		if m.detectDuplicateKeys && len(keys.duplicates) > 0 {
+			return nil, fmt.Errorf("%s: %s at path: %s", errInvalidJSONPattern.Error(), errDuplicateKey.Error(), pathToString(reversePath(keys.duplicates[0])))
+		}
+		if m.strictKeyOrder {
+			p.sourceOrders = keys.orders
+		}
+	} else if expected, err = decodeJSON(expectedJSON, m.useNumber()); err != nil {
+		return nil, newPatternError(expectedJSON, err)
+	}
+	p.expected, err = p.compile(expected, nil)
+	p.sourceOrders = nil
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// A compiledArray is an array of a compiled pattern.
+type compiledArray struct {
+	elements []interface{}
+	// resolved is a value pattern of a value matcher handling the array as a whole, if any
+	resolved *valuePattern
+}
+
+// A compiledObject is an object of a compiled pattern.
+type compiledObject struct {
+	fields map[string]interface{}
+	// resolved is a value pattern of a value matcher handling the object as a whole, if any
+	resolved *valuePattern
+	// keys hold keys in document order, see WithStrictKeyOrder
+	keys []string
+}
+
+// compile converts expected value to its canonical form, see config.compileString,
+// and resolves all its scalar values against value matcher. Arrays and objects
+// are converted to *compiledArray and *compiledObject.
+// Expression patterns are parsed into *exprPattern and patterns having nested
+// patterns, e.g. "@json@(...)", into a nestedPattern.
+func (p *CompiledPattern) compile(expected interface{}, path []interface{}) (interface{}, error) {
+	switch v := expected.(type) {
+	case []interface{}:
+		elements := make([]interface{}, len(v))
+		for i, e := range v {
+			var err error
+			if s, ok := e.(string); ok {
+				elements[i], err = p.compileValue(p.compileElement(s), append(path, i))
+			} else {
+				elements[i], err = p.compile(e, append(path, i))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &compiledArray{elements, p.resolvePattern(v)}, nil
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			f, err := p.compile(e, append(path, k))
+			if err != nil {
+				return nil, err
+			}
+			fields[p.compileKey(k)] = f
+		}
+		o := &compiledObject{fields: fields, resolved: p.resolvePattern(v)}
+		if keys, ok := p.sourceOrders[pathKey(path)]; ok {
+			o.keys = make([]string, len(keys))
+			for i, k := range keys {
+				o.keys[i] = p.compileKey(k)
+			}
+		}
+		return o, nil
+	case string:
+		return p.compileValue(p.compileString(v), path)
+	}
+	return p.resolve(expected), nil
+}
+
+// compileValue parses an expression pattern, a pattern with nested patterns and a pattern
+// with references or resolves other scalar value.
+func (p *CompiledPattern) compileValue(expected interface{}, path []interface{}) (interface{}, error) {
+	s, ok := expected.(string)
+	if !ok {
+		return p.resolve(expected), nil
+	}
+	if n := parseNullPattern(s); n != nil {
+		return n, nil
+	}
+	e, err := parseExprPattern(s)
+	if err == nil && e != nil {
+		return e, nil
+	}
+	if err == nil {
+		var n nestedPattern
+		if n, err = p.parseNestedPattern(s); err == nil && n != nil {
+			// errors of nested patterns are already complete
+			if err := n.compile(p, path); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	if err == nil {
+		var r *referencePattern
+		if r, err = p.parseReferencePattern(s); err == nil && r != nil {
+			return r, nil
+		}
+	}
+	if err != nil {
+		if len(path) > 0 {
+			return nil, fmt.Errorf("%s: %s at path: %s", errInvalidJSONPattern.Error(), err.Error(), pathToString(reversePath(path)))
+		}
+		return nil, fmt.Errorf("%s: %s", errInvalidJSONPattern.Error(), err.Error())
+	}
+	return p.resolve(expected), nil
+}
+
+// resolve checks scalar value against value matcher.
+func (p *CompiledPattern) resolve(expected interface{}) interface{} {
+	if _, ok := p.patterns[expected]; !ok {
+		var resolved *valuePattern
+		if _, isLiteral := expected.(literal); !isLiteral && !isUnbounded(expected) {
+			resolved = p.resolvePattern(expected)
+		}
+		p.patterns[expected] = resolved
+	}
+	return expected
+}
+
+func (p *CompiledPattern) canMatch(expected interface{}) bool {
+	switch expected.(type) {
+	case *exprPattern, *referencePattern, nestedPattern, *nullPattern:
+		return true
+	}
+	return p.pattern(expected) != nil
+}
+
+// pattern returns value pattern resolved when compiling or nil if expected is not a pattern.
+func (p *CompiledPattern) pattern(expected interface{}) *valuePattern {
+	switch e := expected.(type) {
+	case *compiledArray:
+		return e.resolved
+	case *compiledObject:
+		return e.resolved
+	}
+	return p.patterns[expected]
+}
+
+// Match performs deep match of given JSON with the compiled pattern.
+// See JSONMatcher.Match for details.
+func (p *CompiledPattern) Match(actualJSON string) (bool, error) {
+	return p.MatchBytes([]byte(actualJSON))
+}
+
+// MatchBytes works like Match but takes actual JSON as a byte slice.
+func (p *CompiledPattern) MatchBytes(actualJSON []byte) (bool, error) {
+	if p.strictKeyOrder || p.detectDuplicateKeys {
+		actual, keys, err := decodeOrderedJSON(actualJSON, p.useNumber())
+		if err != nil {
+			return false, errInvalidJSON
+		}
+		return p.match(actual, keys)
+	}
+	actual, err := decodeJSON(actualJSON, p.useNumber())
+	if err != nil {
+		return false, errInvalidJSON
+	}
+	return p.match(actual, nil)
+}
+
+// MatchReader works like Match but reads actual JSON from r.
+func (p *CompiledPattern) MatchReader(r io.Reader) (bool, error) {
+	actualJSON, err := ioutil.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	return p.MatchBytes(actualJSON)
+}
+
+// MatchValue works like Match but takes actual value instead of JSON.
+// The value is marshalled with encoding/json so it may be any Go value,
+// e.g. a struct, a value decoded from JSON or a json.RawMessage.
+func (p *CompiledPattern) MatchValue(actual interface{}) (bool, error) {
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", errInvalidJSON.Error(), err.Error())
+	}
+	return p.MatchBytes(actualJSON)
+}
+
+func (p *CompiledPattern) match(actual interface{}, keys *decodedKeys) (bool, error) {
+	s := p.newMatchState(actual)
+	if keys != nil {
+		s.keyOrders = keys.orders
+		if p.detectDuplicateKeys {
+			s.failDuplicateKeys(keys.duplicates)
+		}
+	}
+	if !s.done() {
+		s.deepMatch(p.expected, actual)
+	}
+	if err := s.err(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
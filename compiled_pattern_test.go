@@ -0,0 +1,149 @@
+package gomatch
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var compileErrorTests = []struct {
+	desc   string
+	p      string
+	line   int
+	column int
+	errMsg string
+}{
+	{
+		"Should report location of invalid character",
+		`{"foo":}`,
+		1,
+		8,
+		"invalid JSON pattern: invalid character '}' looking for beginning of value at line 1, column 8",
+	},
+	{
+		"Should report location in multiline pattern",
+		"{\n\t\"id\": 1,\n\t\"name\" \"John\"\n}",
+		3,
+		9,
+		"invalid JSON pattern: invalid character '\"' after object key at line 3, column 9",
+	},
+	{
+		"Should report unexpected end of pattern",
+		`{"foo": "bar"`,
+		1,
+		13,
+		"invalid JSON pattern: unexpected end of JSON input at line 1, column 13",
+	},
+}
+
+func TestCompileErrors(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range compileErrorTests {
+		t.Logf(tt.desc)
+
+		p, err := m.Compile(tt.p)
+
+		assert.Nil(t, p)
+		assert.EqualError(t, err, tt.errMsg)
+		perr, ok := err.(*PatternError)
+		if assert.True(t, ok, "expected *PatternError") {
+			assert.Equal(t, tt.line, perr.Line)
+			assert.Equal(t, tt.column, perr.Column)
+		}
+	}
+}
+
+func TestCompiledPattern(t *testing.T) {
+	m := NewJSONMatcher(NewWildcardMatcher(patternWildcard))
+	for _, tt := range jsonMatcherTests {
+		p, err := m.Compile(tt.p)
+		if err != nil {
+			assert.False(t, tt.ok)
+			continue
+		}
+		ok, err := p.Match(tt.v)
+
+		t.Logf(tt.desc)
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestCompiledPatternConcurrentUse(t *testing.T) {
+	p, err := NewDefaultJSONMatcher().Compile(`{"id": "@number@", "name": "@string@"}`)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := p.Match(fmt.Sprintf(`{"id": %d, "name": "John"}`, i))
+			assert.True(t, ok)
+			assert.Nil(t, err)
+
+			ok, err = p.Match(fmt.Sprintf(`{"id": "%d", "name": "John"}`, i))
+			assert.False(t, ok)
+			assert.EqualError(t, err, "expected number at path: id")
+		}(i)
+	}
+	wg.Wait()
+}
+
+// A countingMatcher counts calls of CanMatch of a value matcher.
+type countingMatcher struct {
+	ValueMatcher
+	calls *int
+}
+
+func (m countingMatcher) CanMatch(p interface{}) bool {
+	*m.calls++
+	return m.ValueMatcher.CanMatch(p)
+}
+
+func TestCompiledPatternResolvesPatternsOnce(t *testing.T) {
+	calls := 0
+	var matchers []ValueMatcher
+	for _, m := range DefaultMatchers() {
+		matchers = append(matchers, countingMatcher{m, &calls})
+	}
+	m := New(WithMatchers(NewChainMatcher(matchers[:5]), NewChainMatcher(matchers[5:])))
+	p, err := m.Compile(`{"id": "@number@.between(1, 10)", "name": "@string@.minLength(2)", "tags": ["@wildcard@"]}`)
+	assert.Nil(t, err)
+	compiled := calls
+
+	for i := 0; i < 100; i++ {
+		ok, err := p.Match(`{"id": 5, "name": "John", "tags": ["a"]}`)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+	ok, err := p.Match(`{"id": 11, "name": "John", "tags": ["a"]}`)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "expected number between 1 and 10 inclusive at path: id")
+
+	assert.Equal(t, compiled, calls, "CanMatch should not be called when matching")
+}
+
+func TestCompiledPatternBuildsExpandersOnce(t *testing.T) {
+	p, err := NewDefaultJSONMatcher().Compile(`{"id": "@number@.between(1, 10)", "name": "@string@.minLength(2)"}`)
+	assert.Nil(t, err)
+
+	vp := p.patterns["@number@.between(1, 10)"]
+	if assert.NotNil(t, vp) {
+		assert.IsType(t, &NumberMatcher{}, vp.matcher, "chained matcher should be resolved when compiling")
+		assert.NotNil(t, vp.check, "expanders should be built when compiling")
+	}
+	vp = p.patterns["@string@.minLength(2)"]
+	if assert.NotNil(t, vp) {
+		assert.IsType(t, &StringMatcher{}, vp.matcher, "chained matcher should be resolved when compiling")
+		assert.NotNil(t, vp.check, "expanders should be built when compiling")
+	}
+}
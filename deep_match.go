@@ -0,0 +1,349 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var errNoMatchingElement = errors.New("no matching array element")
+
+// A MismatchError is returned when more than one mismatch was found.
+// See WithMaxErrors.
+type MismatchError struct {
+	Errors []error
+}
+
+func (e *MismatchError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d mismatches: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// A matchState holds the state of a single deep match of a compiled pattern.
+type matchState struct {
+	p         *CompiledPattern
+	path      []interface{}
+	errs      []error
+	maxErrors int
+	// root is the actual value being matched and parents hold actual arrays and objects
+	// containing the current value, they are used to resolve references of expressions
+	root      interface{}
+	parents   []interface{}
+	streaming bool
+	// keyOrders hold keys of actual objects in document order, see WithStrictKeyOrder
+	keyOrders keyOrders
+	// coercedFrom holds a string of actual JSON coerced to the current value, see WithTypeCoercion
+	coercedFrom *string
+}
+
+func (p *CompiledPattern) newMatchState(actual interface{}) *matchState {
+	return &matchState{p: p, maxErrors: p.maxErrors, root: actual}
+}
+
+// fork creates a state used to check if a value matches without reporting mismatches.
+func (s *matchState) fork() *matchState {
+	return &matchState{
+		p:         s.p,
+		path:      append([]interface{}(nil), s.path...),
+		maxErrors: 1,
+		root:      s.root,
+		parents:   append([]interface{}(nil), s.parents...),
+		streaming: s.streaming,
+		keyOrders: s.keyOrders,
+	}
+}
+
+func (s *matchState) push(key interface{}) {
+	s.path = append(s.path, key)
+}
+
+func (s *matchState) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+func (s *matchState) popParent() {
+	s.parents = s.parents[:len(s.parents)-1]
+}
+
+// done returns true if no more mismatches should be reported.
+func (s *matchState) done() bool {
+	return s.maxErrors > 0 && len(s.errs) >= s.maxErrors
+}
+
+// fail reports a mismatch at current path.
+func (s *matchState) fail(err error) {
+	if s.coercedFrom != nil {
+		err = fmt.Errorf("%s%s", err.Error(), coercionNote(*s.coercedFrom))
+	}
+	if len(s.path) > 0 {
+		err = fmt.Errorf("%s at path: %s", err.Error(), pathToString(reversePath(s.path)))
+	}
+	s.errs = append(s.errs, err)
+}
+
+func (s *matchState) err() error {
+	switch len(s.errs) {
+	case 0:
+		return nil
+	case 1:
+		return s.errs[0]
+	}
+	return &MismatchError{s.errs}
+}
+
+// matches checks if actual value matches expected one without reporting a mismatch.
+func (s *matchState) matches(expected, actual interface{}) bool {
+	f := s.fork()
+	f.deepMatch(expected, actual)
+	return len(f.errs) == 0
+}
+
+func (s *matchState) deepMatch(expected interface{}, actual interface{}) {
+	if len(s.p.ignored) > 0 && s.ignored() {
+		return
+	}
+	switch e := expected.(type) {
+	case literal:
+		s.matchLiteral(e, actual)
+		return
+	case *exprPattern:
+		if err := e.match(s, actual); err != nil {
+			s.fail(err)
+		}
+		return
+	case *referencePattern:
+		if err := e.match(s, actual); err != nil {
+			s.fail(err)
+		}
+		return
+	case nestedPattern:
+		e.match(s, actual)
+		return
+	case *nullPattern:
+		if actual != nil {
+			s.fail(errNotNull)
+		}
+		return
+	}
+	if valueType(expected) != reflect.TypeOf(actual) && !s.p.canMatch(expected) {
+		if s.p.coerceTypes {
+			if coerced, ok := s.p.coerce(actual); ok && reflect.TypeOf(coerced) == valueType(expected) {
+				s.matchCoerced(expected, coerced, actual.(string))
+				return
+			}
+		}
+		s.fail(errTypesNotEqual)
+		return
+	}
+
+	switch e := expected.(type) {
+	case *compiledArray:
+		s.parents = append(s.parents, actual)
+		defer s.popParent()
+		if s.p.unorderedArrays {
+			s.deepMatchUnorderedArray(e.elements, actual.([]interface{}))
+			return
+		}
+		s.deepMatchArray(e.elements, actual.([]interface{}))
+
+	case *compiledObject:
+		s.parents = append(s.parents, actual)
+		defer s.popParent()
+		s.deepMatchMap(e, actual.(map[string]interface{}))
+
+	default:
+		s.matchValue(expected, actual)
+	}
+}
+
+func (s *matchState) deepMatchArray(expected, actual []interface{}) {
+	unbounded := false
+	for i, v := range expected {
+		if isUnbounded(v) {
+			unbounded = true
+			break
+		}
+		if i == len(actual) || s.done() {
+			break
+		}
+		s.push(i)
+		s.deepMatch(v, actual[i])
+		s.pop()
+	}
+	if !unbounded && len(expected) != len(actual) && !s.done() {
+		s.fail(errArraysLenNotEqual)
+	}
+}
+
+// deepMatchUnorderedArray matches every expected element with a different actual element.
+// It uses augmenting paths to find maximum matching, so a greedy choice of an element
+// does not hide a valid assignment.
+func (s *matchState) deepMatchUnorderedArray(expected, actual []interface{}) {
+	unbounded := false
+	var elements []interface{}
+	var indexes []int
+	for i, v := range expected {
+		if isUnbounded(v) {
+			unbounded = true
+			continue
+		}
+		elements = append(elements, v)
+		indexes = append(indexes, i)
+	}
+	if len(elements) > len(actual) || !unbounded && len(elements) != len(actual) {
+		s.fail(errArraysLenNotEqual)
+		return
+	}
+	candidates := make([][]int, len(elements))
+	for i, v := range elements {
+		for j, a := range actual {
+			// the path of actual element is needed to check ignored paths
+			s.push(j)
+			if s.matches(v, a) {
+				candidates[i] = append(candidates[i], j)
+			}
+			s.pop()
+		}
+	}
+	assigned := make([]int, len(actual))
+	for j := range assigned {
+		assigned[j] = -1
+	}
+	var assign func(i int, visited []bool) bool
+	assign = func(i int, visited []bool) bool {
+		for _, j := range candidates[i] {
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if assigned[j] == -1 || assign(assigned[j], visited) {
+				assigned[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range elements {
+		if s.done() {
+			return
+		}
+		if !assign(i, make([]bool, len(actual))) {
+			s.push(indexes[i])
+			s.fail(errNoMatchingElement)
+			s.pop()
+		}
+	}
+}
+
+func (s *matchState) deepMatchMap(compiled *compiledObject, actual map[string]interface{}) {
+	if s.p.strictKeyOrder {
+		s.checkKeyOrder(compiled)
+	}
+	expected := compiled.fields
+	unbounded := false
+	for _, k := range sortedKeys(expected) {
+		if s.done() {
+			return
+		}
+		if isUnbounded(k) {
+			unbounded = true
+			continue
+		}
+		v2, ok := actual[k]
+		if !ok {
+			if !s.p.allowsMissing(expected[k]) && !s.ignoredKey(k) {
+				s.fail(fmt.Errorf(`expected key "%s"`, k))
+			}
+			continue
+		}
+		s.push(k)
+		s.deepMatch(expected[k], v2)
+		s.pop()
+	}
+	if unbounded {
+		return
+	}
+	for _, k := range sortedKeys(actual) {
+		if s.done() {
+			return
+		}
+		if _, ok := expected[k]; !ok && !s.p.allowsUnexpected(actual[k]) && !s.ignoredKey(k) {
+			s.push(k)
+			s.fail(errUnexpectedKey)
+			s.pop()
+		}
+	}
+}
+
+func (s *matchState) matchValue(expected, actual interface{}) {
+	if vp := s.p.pattern(expected); vp != nil {
+		err := vp.match(s.p.registry, actual)
+		if err == nil {
+			return
+		}
+		if s.p.coerceTypes && isTypeMismatch(err) {
+			if coerced, ok := s.p.coerce(actual); ok {
+				cerr := vp.match(s.p.registry, coerced)
+				if cerr == nil {
+					return
+				}
+				err = fmt.Errorf("%s%s", cerr.Error(), coercionNote(actual.(string)))
+			}
+		}
+		s.fail(err)
+		return
+	}
+	if s.p.numberEpsilon != nil && isNumber(expected) {
+		if !numbersWithin(expected, actual, s.p.numberEpsilon) {
+			s.fail(errValuesNotEqual)
+		}
+		return
+	}
+	if n, ok := expected.(json.Number); ok {
+		if !numbersEqual(n, actual.(json.Number)) {
+			s.fail(errValuesNotEqual)
+		}
+		return
+	}
+	if expected != actual {
+		s.fail(errValuesNotEqual)
+	}
+}
+
+func (s *matchState) matchLiteral(expected literal, actual interface{}) {
+	a, ok := actual.(string)
+	if !ok {
+		s.fail(errTypesNotEqual)
+		return
+	}
+	if string(expected) != a {
+		s.fail(errValuesNotEqual)
+	}
+}
+
+// valueType returns the type of actual values of the same kind as expected value,
+// arrays and objects of a compiled pattern are matched by []interface{} and map[string]interface{}.
+func valueType(expected interface{}) reflect.Type {
+	switch expected.(type) {
+	case *compiledArray:
+		return reflect.TypeOf([]interface{}(nil))
+	case *compiledObject:
+		return reflect.TypeOf(map[string]interface{}(nil))
+	}
+	return reflect.TypeOf(expected)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
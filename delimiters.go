@@ -0,0 +1,131 @@
+package gomatch
+
+import "strings"
+
+const defaultDelimiter = "@"
+
+// unboundedMarker replaces the unbounded pattern in compiled patterns.
+// It is not a valid UTF-8 string so it can't be decoded from JSON
+// and can't be confused with a key or a value of actual JSON.
+const unboundedMarker = "\xff" + patternUnbounded
+
+// A literal is a string of a compiled pattern which has to be compared as is,
+// even if it looks like a pattern, e.g. an escaped pattern "@@string@@".
+type literal string
+
+// WithPatternDelimiters sets delimiters used to mark patterns in expected JSON.
+// With WithPatternDelimiters("{{", "}}") patterns look like "{{string}}" and "{{...}}",
+// while "@string@" is a literal string.
+//
+// Value matchers keep using patterns with default "@" delimiter,
+// e.g. "{{number}}" is handled by a matcher supporting "@number@" pattern.
+//
+// Doubled delimiters escape a pattern, e.g. "{{{{string}}}}" or "@@string@@"
+// for default delimiters match literal "{{string}}" and "@string@" respectively.
+// Strings which are not known patterns, e.g. "{{name}}", are compared as they are.
+func WithPatternDelimiters(open, close string) Option {
+	return func(c *config) {
+		c.openDelimiter, c.closeDelimiter = open, close
+	}
+}
+
+func (c *config) delimiters() (string, string) {
+	if c.openDelimiter == "" || c.closeDelimiter == "" {
+		return defaultDelimiter, defaultDelimiter
+	}
+	return c.openDelimiter, c.closeDelimiter
+}
+
+// compileString converts a string of expected JSON to its canonical form
+// where patterns use default delimiters. Strings which have to be compared as is
+// are returned as a literal.
+//
+// Only strings which are patterns are converted or unescaped, other strings,
+// e.g. "{{name}}" of a template or "@@a@@ and @@b@@", are compared as they are.
+func (c *config) compileString(s string) interface{} {
+	open, close := c.delimiters()
+	if strings.HasPrefix(s, open+open) {
+		rest := s[2*len(open):]
+		if i := strings.Index(rest, close+close); i > -1 {
+			name, tail := rest[:i], rest[i+2*len(close):]
+			if c.isPattern(defaultDelimiter + name + defaultDelimiter + tail) {
+				return literal(open + name + close + tail)
+			}
+		}
+	}
+	if open == defaultDelimiter && close == defaultDelimiter {
+		return s
+	}
+	if p, ok := c.withDefaultDelimiters(s); ok && c.isPattern(p) {
+		return p
+	}
+	if patternLikeRe.MatchString(s) {
+		return literal(s)
+	}
+	return s
+}
+
+// withDefaultDelimiters returns s with delimiters of a leading pattern replaced with default ones.
+// It returns false if s does not start with a pattern.
+func (c *config) withDefaultDelimiters(s string) (string, bool) {
+	open, close := c.delimiters()
+	if !strings.HasPrefix(s, open) {
+		return "", false
+	}
+	rest := s[len(open):]
+	i := strings.Index(rest, close)
+	if i < 0 {
+		return "", false
+	}
+	return defaultDelimiter + rest[:i] + defaultDelimiter + rest[i+len(close):], true
+}
+
+// isPattern returns true if s having default delimiters is a pattern: the unbounded pattern,
+// a value pattern or a pattern handled by a compiled pattern, even an invalid one.
+func (c *config) isPattern(s string) bool {
+	if s == patternUnbounded || parseNullPattern(s) != nil || c.resolvePattern(s) != nil {
+		return true
+	}
+	if e, err := parseExprPattern(s); e != nil || err != nil {
+		return true
+	}
+	if n, err := c.parseNestedPattern(s); n != nil || err != nil {
+		return true
+	}
+	r, err := c.parseReferencePattern(s)
+	return r != nil || err != nil
+}
+
+// patternLike returns true if s looks like a pattern having configured delimiters.
+// Strings starting with doubled delimiters look like escaped patterns.
+func (c *config) patternLike(s string) bool {
+	open, _ := c.delimiters()
+	if strings.HasPrefix(s, open+open) {
+		return false
+	}
+	p, ok := c.withDefaultDelimiters(s)
+	return ok && patternLikeRe.MatchString(p)
+}
+
+// compileKey converts a key of expected JSON replacing the unbounded pattern with a marker.
+func (c *config) compileKey(k string) string {
+	switch v := c.compileString(k).(type) {
+	case literal:
+		return string(v)
+	case string:
+		if v == patternUnbounded {
+			return unboundedMarker
+		}
+	}
+	return k
+}
+
+// compileElement converts an array element of expected JSON replacing
+// the unbounded pattern with a marker.
+func (c *config) compileElement(s string) interface{} {
+	v := c.compileString(s)
+	if v == patternUnbounded {
+		return unboundedMarker
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var delimitersTests = []struct {
+	desc   string
+	opts   []Option
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{
+		"Should match escaped pattern literally",
+		nil,
+		`{"template": "@@string@@"}`,
+		`{"template": "@string@"}`,
+		true,
+		"",
+	},
+	{
+		"Should not use escaped pattern as a pattern",
+		nil,
+		`{"template": "@@string@@"}`,
+		`{"template": "John"}`,
+		false,
+		"values are not equal at path: template",
+	},
+	{
+		"Should match escaped pattern with expanders literally",
+		nil,
+		`"@@string@@.minLength(3)"`,
+		`"@string@.minLength(3)"`,
+		true,
+		"",
+	},
+	{
+		"Should not unescape a string which is not a pattern",
+		nil,
+		`"@@a@@ and @@b@@"`,
+		`"@@a@@ and @@b@@"`,
+		true,
+		"",
+	},
+	{
+		"Should not unescape a pattern with surrounding text",
+		nil,
+		`"@@string@@ is a pattern"`,
+		`"@string@ is a pattern"`,
+		false,
+		"values are not equal",
+	},
+	{
+		"Should match escaped unbounded pattern as an array element literally",
+		nil,
+		`["@@...@@"]`,
+		`["@...@"]`,
+		true,
+		"",
+	},
+	{
+		"Should not use escaped unbounded pattern as an array element",
+		nil,
+		`[1, "@@...@@"]`,
+		`[1, "2"]`,
+		false,
+		"values are not equal at path: [1]",
+	},
+	{
+		"Should match escaped unbounded pattern as a key literally",
+		nil,
+		`{"@@...@@": 1}`,
+		`{"@...@": 1}`,
+		true,
+		"",
+	},
+	{
+		"Should not use escaped unbounded pattern as a key",
+		nil,
+		`{"@@...@@": 1}`,
+		`{"@...@": 1, "id": 1}`,
+		false,
+		"unexpected key at path: id",
+	},
+	{
+		"Should use custom delimiters",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"id": "{{number}}", "tags": ["a", "{{...}}"], "{{...}}": ""}`,
+		`{"id": 1, "tags": ["a", "b"], "name": "John"}`,
+		true,
+		"",
+	},
+	{
+		"Should match default delimiters literally when custom delimiters are used",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"template": "@string@", "items": ["@...@"], "@...@": 1}`,
+		`{"template": "@string@", "items": ["@...@"], "@...@": 1}`,
+		true,
+		"",
+	},
+	{
+		"Should not use default delimiters when custom delimiters are used",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"template": "@string@"}`,
+		`{"template": "John"}`,
+		false,
+		"values are not equal at path: template",
+	},
+	{
+		"Should escape custom delimiters",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"template": "{{{{string}}}}"}`,
+		`{"template": "{{string}}"}`,
+		true,
+		"",
+	},
+	{
+		"Should match unknown custom delimited string literally",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"t": "{{name}}", "s": "{{name}} is here", "u": "Hello {{name}}"}`,
+		`{"t": "{{name}}", "s": "{{name}} is here", "u": "Hello {{name}}"}`,
+		true,
+		"",
+	},
+	{
+		"Should not match unknown custom delimited string with other values",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"t": "{{name}}"}`,
+		`{"t": "@name@"}`,
+		false,
+		"values are not equal at path: t",
+	},
+	{
+		"Should not unescape custom delimited string which is not a pattern",
+		[]Option{WithPatternDelimiters("{{", "}}")},
+		`{"t": "{{{{name}}}}"}`,
+		`{"t": "{{{{name}}}}"}`,
+		true,
+		"",
+	},
+	{
+		"Should support different open and close delimiters",
+		[]Option{WithPatternDelimiters("<", ">")},
+		`{"id": "<number>", "@...@": ""}`,
+		`{"id": 1, "@...@": ""}`,
+		true,
+		"",
+	},
+}
+
+func TestPatternDelimiters(t *testing.T) {
+	for _, tt := range delimitersTests {
+		t.Logf(tt.desc)
+		m := NewDefaultJSONMatcher(tt.opts...)
+
+		ok, err := m.Match(tt.p, tt.v)
+		streamOk, streamErr := m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+			assert.Nil(t, streamErr)
+			assert.True(t, streamOk)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+			assert.EqualError(t, streamErr, tt.errMsg)
+			assert.False(t, streamOk)
+		}
+	}
+}
+
+func TestLintWithPatternDelimiters(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithPatternDelimiters("{{", "}}"))
+
+	issues, err := m.Lint(`{"id": "{{nubmer}}", "email": "@email@", "escaped": "{{{{nubmer}}}}", "items": ["{{...}}", 1]}`)
+
+	assert.Nil(t, err)
+	if assert.Len(t, issues, 2) {
+		assert.Equal(t, `unknown pattern "{{nubmer}}" will be compared as a string`, issues[0].Msg)
+		assert.Equal(t, `unbounded pattern "{{...}}" has no effect unless it is the last array element`, issues[1].Msg)
+	}
+}
@@ -0,0 +1,17 @@
+package gomatch
+
+import "errors"
+
+var errDuplicateKey = errors.New("duplicate key")
+
+// failDuplicateKeys reports duplicate keys of actual JSON found when it was decoded.
+func (s *matchState) failDuplicateKeys(paths [][]interface{}) {
+	for _, path := range paths {
+		if s.done() {
+			return
+		}
+		s.path = path
+		s.fail(errDuplicateKey)
+	}
+	s.path = nil
+}
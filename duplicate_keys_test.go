@@ -0,0 +1,72 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var duplicateKeyTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match without duplicate keys", `{"a": 1, "b": {"c": 2}}`, `{"a": 1, "b": {"c": 2}}`, true, ""},
+	{"Should report duplicate key", `{"a": 1}`, `{"a": 2, "a": 1}`, false, "duplicate key at path: a"},
+	{"Should report path of nested duplicate key", `{"items": "@array@"}`, `{"items": [{"id": 1}, {"id": 1, "id": 2}]}`, false, "duplicate key at path: items[1].id"},
+	{"Should report duplicate key of unbounded object", `{"@...@": ""}`, `{"x": 1, "x": 1}`, false, "duplicate key at path: x"},
+}
+
+func TestDuplicateKeyDetection(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithDuplicateKeyDetection())
+	for _, tt := range duplicateKeyTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestDuplicateKeyDetectionMaxErrors(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithDuplicateKeyDetection(), WithMaxErrors(0))
+
+	ok, err := m.Match(`{"a": 1, "b": [{"c": 1}]}`, `{"a": 2, "b": [{"c": 1, "c": 1}], "a": 3}`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "3 mismatches: duplicate key at path: b[0].c; duplicate key at path: a; values are not equal at path: a")
+}
+
+func TestDuplicateKeyDetectionPattern(t *testing.T) {
+	_, err := NewDefaultJSONMatcher(WithDuplicateKeyDetection()).Compile(`{"user": {"id": 1, "id": "@number@"}}`)
+
+	assert.EqualError(t, err, "invalid JSON pattern: duplicate key at path: user.id")
+
+	_, err = NewDefaultJSONMatcher().Compile(`{"user": {"id": 1, "id": "@number@"}}`)
+
+	assert.Nil(t, err)
+}
+
+func TestDuplicateKeyDetectionDisabled(t *testing.T) {
+	ok, err := NewDefaultJSONMatcher().Match(`{"a": 1}`, `{"a": 2, "a": 1}`)
+
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestDuplicateKeyDetectionStream(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithDuplicateKeyDetection())
+
+	ok, err := m.MatchStream(`{"a": 1, "@...@": ""}`, strings.NewReader(`{"a": 1, "x": 1, "x": 2}`))
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "duplicate key at path: x")
+}
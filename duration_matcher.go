@@ -0,0 +1,139 @@
+package gomatch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var errNotDuration = errors.New("expected duration")
+
+// A DurationMatcher matches durations written in ISO 8601 format, e.g. "PT30S" or "P1DT12H",
+// or as Go duration strings, e.g. "1h30m" or "300ms".
+//
+// Supported expanders:
+//
+//  @duration@.min("1s")
+//  @duration@.max("PT1H")
+//
+// Bounds may be written in any of supported formats. Durations in ISO 8601 format
+// are converted assuming a day of 24 hours, a week of 7 days, a month of 30 days
+// and a year of 365 days.
+type DurationMatcher struct {
+	pattern string
+}
+
+var durationExpanders = expanderSet{
+	"min": durationBound("at least", func(d, bound time.Duration) bool { return d >= bound }),
+	"max": durationBound("at most", func(d, bound time.Duration) bool { return d <= bound }),
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *DurationMatcher) CanMatch(p interface{}) bool {
+	_, ok := durationExpanders.build(p, m.pattern)
+	return ok
+}
+
+// Match performs value matching against given pattern.
+func (m *DurationMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *DurationMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return durationExpanders.compile(p, m.pattern, validateDuration)
+}
+
+func (m *DurationMatcher) patternExpanders() expanderSet {
+	return durationExpanders
+}
+
+func validateDuration(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return errNotDuration
+	}
+	if _, ok := parseDuration(s); !ok {
+		return errNotDuration
+	}
+	return nil
+}
+
+// NewDurationMatcher creates DurationMatcher.
+func NewDurationMatcher(pattern string) *DurationMatcher {
+	return &DurationMatcher{pattern}
+}
+
+func durationBound(desc string, fn func(d, bound time.Duration) bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 1); err != nil {
+			return nil, err
+		}
+		if !args[0].isString() {
+			return nil, errNotString
+		}
+		bound, ok := parseDuration(args[0].String())
+		if !ok {
+			return nil, errInvalidArgs
+		}
+		return func(v interface{}) error {
+			d, _ := parseDuration(v.(string))
+			if !fn(d, bound) {
+				return fmt.Errorf("expected duration %s %s, got %s", desc, bound, d)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// parseDuration parses a duration in ISO 8601 or Go format.
+func parseDuration(s string) (time.Duration, bool) {
+	if strings.HasPrefix(s, "P") {
+		return parseISODuration(s)
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
+
+var isoDurationRegexp = regexp.MustCompile(
+	`^P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?` +
+		`(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// isoDurationUnits holds durations of ISO 8601 duration components in order of appearance.
+var isoDurationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// parseISODuration parses ISO 8601 duration, e.g. "P1Y2M3DT4H5M6.5S" or "P2W".
+func parseISODuration(s string) (time.Duration, bool) {
+	m := isoDurationRegexp.FindStringSubmatch(s)
+	// "P" and "PT" alone are not valid
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, false
+	}
+	total := new(big.Rat)
+	for i, unit := range isoDurationUnits {
+		if m[i+1] == "" {
+			continue
+		}
+		r, ok := new(big.Rat).SetString(strings.Replace(m[i+1], ",", ".", 1))
+		if !ok {
+			return 0, false
+		}
+		total.Add(total, r.Mul(r, new(big.Rat).SetInt64(int64(unit))))
+	}
+	n := new(big.Int).Quo(total.Num(), total.Denom())
+	if n.Cmp(big.NewInt(math.MaxInt64)) > 0 {
+		return 0, false
+	}
+	return time.Duration(n.Int64()), true
+}
@@ -0,0 +1,56 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var durationMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match ISO 8601 duration", "@duration@", "PT30S", true, ""},
+	{"Should match ISO 8601 duration with all components", "@duration@", "P1Y2M3DT4H5M6.5S", true, ""},
+	{"Should match ISO 8601 duration in weeks", "@duration@", "P2W", true, ""},
+	{"Should match Go duration", "@duration@", "1h30m", true, ""},
+	{"Should not match empty ISO 8601 duration", "@duration@", "P", false, "expected duration"},
+	{"Should not match ISO 8601 duration without time", "@duration@", "P1DT", false, "expected duration"},
+	{"Should not match components out of order", "@duration@", "PT5S1M", false, "expected duration"},
+	{"Should not match too long duration", "@duration@", "P1000Y", false, "expected duration"},
+	{"Should not match number", "@duration@", 30., false, "expected duration"},
+	{"Should not match text", "@duration@", "30 seconds", false, "expected duration"},
+	{"Should match duration in bounds", `@duration@.min("1s").max("PT1M")`, "PT30S", true, ""},
+	{"Should compare durations in different formats", `@duration@.min("PT1H30M").max("PT1H30M")`, "1h30m", true, ""},
+	{"Should convert fractions", `@duration@.min("1.5s").max("1.5s")`, "PT1,5S", true, ""},
+	{"Should not match too short duration", `@duration@.min("1s")`, "500ms", false, "expected duration at least 1s, got 500ms"},
+	{"Should not match too long duration", `@duration@.max("P1D")`, "P1DT1S", false, "expected duration at most 24h0m0s, got 24h0m1s"},
+}
+
+func TestDurationMatcher(t *testing.T) {
+	m := NewDurationMatcher("@duration@")
+	for _, tt := range durationMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestDurationMatcherInvalidExpanders(t *testing.T) {
+	m := NewDurationMatcher("@duration@")
+	for _, p := range []string{"@duration@.min(1)", `@duration@.max("1 hour")`, "@duration@.min()", "@duration@.unknown()"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
@@ -2,37 +2,173 @@ package gomatch
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
 )
 
 var emailRe = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 
 var errNotEmail = errors.New("expected email")
 
+// Length limits of an email address, see RFC 5321 section 4.5.3.1.
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+	maxEmailLabelLength = 63
+)
+
+const emailInternational = "international"
+
 // An EmailMatcher matches email
+//
+// Addresses longer than 254 bytes or with a local part longer than 64 bytes are rejected.
+//
+// Supported expanders:
+//
+//  @email@.domain("example.com", "example.org")   // one of domains, case insensitive
+//  @email@.noPlus()                               // no plus-addressing, e.g. "joe+tag@example.com"
+//  @email@.international()                        // allow UTF-8 local parts and IDN domains (RFC 6531)
 type EmailMatcher struct {
 	pattern string
 }
 
+var emailExpanders = expanderSet{
+	"domain":           emailDomain,
+	"noPlus":           emailNoPlus,
+	emailInternational: emailAllowInternational,
+}
+
 // CanMatch returns true if pattern p can be handled
 func (m *EmailMatcher) CanMatch(p interface{}) bool {
-	return isPattern(p, m.pattern)
+	_, ok := emailExpanders.build(p, m.pattern)
+	return ok
 }
 
 // Match performs value matching against given pattern.
 func (m *EmailMatcher) Match(p, v interface{}) (bool, error) {
-	s, ok := v.(string)
-	if !ok {
-		return false, errNotEmail
-	}
-	ok = emailRe.MatchString(s)
-	if !ok {
-		return false, errNotEmail
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *EmailMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	international := m.international(p)
+	return emailExpanders.compile(p, m.pattern, func(v interface{}) error {
+		if s, ok := v.(string); !ok || !isEmail(s, international) {
+			return errNotEmail
+		}
+		return nil
+	})
+}
+
+func (m *EmailMatcher) patternExpanders() expanderSet {
+	return emailExpanders
+}
+
+// international returns true if pattern p allows internationalised addresses.
+func (m *EmailMatcher) international(p interface{}) bool {
+	parsed, _ := matchPattern(p, m.pattern)
+	for _, e := range parsed.expanders {
+		if e.name == emailInternational {
+			return true
+		}
 	}
-	return true, nil
+	return false
 }
 
 // NewEmailMatcher creates EmailMatcher.
 func NewEmailMatcher(pattern string) *EmailMatcher {
 	return &EmailMatcher{pattern}
 }
+
+func isEmail(s string, international bool) bool {
+	i := strings.LastIndexByte(s, '@')
+	if len(s) > maxEmailLength || i < 0 || i > maxEmailLocalLength {
+		return false
+	}
+	if !international {
+		return emailRe.MatchString(s)
+	}
+	return isInternationalLocalPart(s[:i]) && isInternationalDomain(s[i+1:])
+}
+
+// isInternationalLocalPart checks dot-atom local part allowing UTF-8 characters.
+func isInternationalLocalPart(s string) bool {
+	for _, atom := range strings.Split(s, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if r < 0x80 && !isIdentByte(byte(r)) && !strings.ContainsRune("!#$%&'*+/=?^`{|}~-", r) {
+				return false
+			}
+			if r >= 0x80 && !unicode.IsPrint(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isInternationalDomain checks domain with labels consisting of letters, digits and hyphens
+// of any script.
+func isInternationalDomain(s string) bool {
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > maxEmailLabelLength ||
+			strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func emailDomain(args []patternArg) (func(v interface{}) error, error) {
+	if len(args) == 0 {
+		return nil, errInvalidArgs
+	}
+	domains := make([]string, len(args))
+	for i, a := range args {
+		if !a.isString() {
+			return nil, errNotString
+		}
+		domains[i] = a.String()
+	}
+	return func(v interface{}) error {
+		s := v.(string)
+		domain := s[strings.LastIndexByte(s, '@')+1:]
+		for _, d := range domains {
+			if strings.EqualFold(domain, d) {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected email in domain %s", strings.Join(domains, ", "))
+	}, nil
+}
+
+func emailNoPlus(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		s := v.(string)
+		if strings.Contains(s[:strings.LastIndexByte(s, '@')], "+") {
+			return errors.New("expected email without plus-addressing")
+		}
+		return nil
+	}, nil
+}
+
+// emailAllowInternational only marks the pattern, see EmailMatcher.international.
+func emailAllowInternational(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		return nil
+	}, nil
+}
@@ -1,6 +1,7 @@
 package gomatch
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,6 +69,17 @@ var emailMatcherTests = []struct {
 	},
 }
 
+func TestEmailMatcherLengthLimits(t *testing.T) {
+	m := NewEmailMatcher("@email@")
+
+	ok, _ := m.Match("@email@", strings.Repeat("a", 64)+"@"+strings.Repeat("b", 63)+".com")
+	assert.True(t, ok)
+	ok, _ = m.Match("@email@", strings.Repeat("a", 65)+"@example.com")
+	assert.False(t, ok, "local part longer than 64")
+	ok, _ = m.Match("@email@", "a@"+strings.Repeat(strings.Repeat("b", 60)+".", 5)+"com")
+	assert.False(t, ok, "address longer than 254")
+}
+
 func TestEmailMatcher(t *testing.T) {
 	pattern := "@pattern@"
 
@@ -88,3 +100,48 @@ func TestEmailMatcher(t *testing.T) {
 		}
 	}
 }
+
+var emailExpanderTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match email in domain", `@email@.domain("example.com", "example.org")`, "joe@Example.ORG", true, ""},
+	{"Should not match email in other domain", `@email@.domain("example.com")`, "joe@example.com.evil.io", false, "expected email in domain example.com"},
+	{"Should match email without plus-addressing", "@email@.noPlus()", "joe@example.com", true, ""},
+	{"Should not match email with plus-addressing", "@email@.noPlus()", "joe+tag@example.com", false, "expected email without plus-addressing"},
+	{"Should not match international email by default", "@email@", "józef@przykład.pl", false, "expected email"},
+	{"Should match international email", "@email@.international()", "józef@przykład.pl", true, ""},
+	{"Should match international email in domain", `@email@.international().domain("例え.jp")`, "ユーザー@例え.jp", true, ""},
+	{"Should match ASCII email as international", "@email@.international()", "joe.doe@gmail.com", true, ""},
+	{"Should not match international email with empty atom", "@email@.international()", "józef..k@przykład.pl", false, "expected email"},
+	{"Should not match international email with invalid domain", "@email@.international()", "józef@-przykład.pl", false, "expected email"},
+	{"Should not match international email with spaces", "@email@.international()", "józef k@przykład.pl", false, "expected email"},
+}
+
+func TestEmailMatcherExpanders(t *testing.T) {
+	m := NewEmailMatcher("@email@")
+	for _, tt := range emailExpanderTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestEmailMatcherInvalidExpanders(t *testing.T) {
+	m := NewEmailMatcher("@email@")
+	for _, p := range []string{"@email@.domain()", "@email@.domain(1)", "@email@.noPlus(1)", "@email@.international(true)"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
@@ -0,0 +1,104 @@
+package gomatch
+
+import (
+	"errors"
+	"fmt"
+)
+
+const patternJSON = "json"
+
+var errNotJSONString = errors.New("expected string containing JSON")
+
+// A nestedPattern is a pattern of a compiled pattern which decodes a value
+// and matches the result with nested patterns, e.g. "@json@(...)".
+type nestedPattern interface {
+	// compile compiles nested patterns, path is a path of the pattern
+	compile(p *CompiledPattern, path []interface{}) error
+	match(s *matchState, v interface{})
+}
+
+// parseNestedPattern parses s if it is a pattern with nested patterns:
+// "@json@", "@base64@", "@jwt@" or "@array@.every(...)". It returns nil if s is not such pattern.
+func (c *config) parseNestedPattern(s string) (nestedPattern, error) {
+	parsed, ok := parsePattern(s)
+	if !ok {
+		return nil, nil
+	}
+	switch parsed.name {
+	case patternJSON:
+		return c.parseEmbeddedPattern(s, parsed)
+	case patternBase64:
+		return c.parseBase64Pattern(s, parsed)
+	case patternJWT:
+		return c.parseJWTPattern(s, parsed)
+	}
+	return c.parseEveryPattern(s, parsed)
+}
+
+// decodeNestedPattern decodes a nested pattern given as a pattern argument.
+func (c *config) decodeNestedPattern(s string, arg patternArg) (interface{}, error) {
+	nested, err := decodeJSON([]byte(arg), c.useNumber())
+	if err != nil {
+		return nil, fmt.Errorf(`invalid nested pattern of "%s": %s`, s, err.Error())
+	}
+	return nested, nil
+}
+
+// An embeddedPattern is a "@json@" pattern of a compiled pattern. It matches a string
+// containing JSON, e.g. a double-encoded event payload, and optionally matches
+// the decoded document with a nested pattern:
+//
+//  "@json@"
+//  "@json@({\"id\": \"@number@\", \"tags\": [\"@string@\", \"@...@\"]})"
+//
+// The nested pattern is compiled with the outer one and matched with the same settings.
+// Paths of mismatches continue into the embedded document, e.g. "payload.id",
+// and references, e.g. "$.id", still point to the outer document.
+type embeddedPattern struct {
+	expected interface{}
+	// any is true for a pattern without a nested pattern matching any JSON
+	any bool
+}
+
+func (c *config) parseEmbeddedPattern(s string, parsed *parsedPattern) (nestedPattern, error) {
+	if len(parsed.expanders) > 0 {
+		return nil, nil
+	}
+	if !parsed.hasArgs {
+		return &embeddedPattern{any: true}, nil
+	}
+	args, err := parseArgs(parsed.args)
+	if err != nil || len(args) != 1 {
+		return nil, fmt.Errorf(`invalid embedded JSON pattern "%s": expected a single JSON argument`, s)
+	}
+	nested, err := c.decodeNestedPattern(s, args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &embeddedPattern{expected: nested}, nil
+}
+
+func (e *embeddedPattern) compile(p *CompiledPattern, path []interface{}) error {
+	if e.any {
+		return nil
+	}
+	var err error
+	e.expected, err = p.compile(e.expected, path)
+	return err
+}
+
+func (e *embeddedPattern) match(s *matchState, v interface{}) {
+	str, ok := v.(string)
+	if !ok {
+		s.fail(errNotJSONString)
+		return
+	}
+	actual, err := decodeJSON([]byte(str), s.p.useNumber())
+	if err != nil {
+		s.fail(errNotJSONString)
+		return
+	}
+	if !e.any {
+		s.deepMatch(e.expected, actual)
+	}
+}
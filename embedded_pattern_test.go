@@ -0,0 +1,115 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var embeddedPatternTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match string containing any JSON", `{"payload": "@json@"}`, `{"payload": "[1, 2]"}`, true, ""},
+	{"Should not match string containing invalid JSON", `{"payload": "@json@"}`, `{"payload": "{"}`, false, "expected string containing JSON at path: payload"},
+	{"Should not match not a string", `{"payload": "@json@"}`, `{"payload": {}}`, false, "expected string containing JSON at path: payload"},
+	{
+		"Should match embedded JSON with nested pattern",
+		`{"payload": "@json@({\"id\": \"@number@\", \"tags\": [\"@string@\", \"@...@\"]})"}`,
+		`{"payload": "{\"id\": 5, \"tags\": [\"a\", \"b\"]}"}`,
+		true,
+		"",
+	},
+	{
+		"Should report path inside embedded JSON",
+		`{"payload": "@json@({\"user\": {\"id\": \"@number@\"}})"}`,
+		`{"payload": "{\"user\": {\"id\": \"5\"}}"}`,
+		false,
+		"expected number at path: payload.user.id",
+	},
+	{
+		"Should match nested embedded JSON",
+		`{"event": "@json@({\"payload\": \"@json@(\\\"@string@\\\")\"})"}`,
+		`{"event": "{\"payload\": \"\\\"a\\\"\"}"}`,
+		true,
+		"",
+	},
+	{
+		"Should resolve references in outer document",
+		`{"id": "@number@", "payload": "@json@({\"id\": \"@number@.equals($.id)\"})"}`,
+		`{"id": 5, "payload": "{\"id\": 6}"}`,
+		false,
+		"expected number equal to 5 at path: payload.id",
+	},
+	{"Should use pattern arguments inside delimiters", `"@json(\"@string@\")@"`, `"\"a\""`, true, ""},
+}
+
+func TestEmbeddedPattern(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range embeddedPatternTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestEmbeddedPatternMaxErrors(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithMaxErrors(0))
+
+	ok, err := m.Match(`{"payload": "@json@({\"a\": \"@number@\", \"b\": \"@bool@\"})"}`, `{"payload": "{\"a\": \"x\", \"b\": 1}"}`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "2 mismatches: expected number at path: payload.a; expected bool at path: payload.b")
+}
+
+func TestEmbeddedPatternWithDelimiters(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithPatternDelimiters("{{", "}}"))
+
+	ok, err := m.Match(`{"payload": "{{json}}({\"id\": \"{{number}}\"})"}`, `{"payload": "{\"id\": 1}"}`)
+
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestEmbeddedPatternStream(t *testing.T) {
+	p, err := NewDefaultJSONMatcher().Compile(`[{"payload": "@json@({\"id\": \"@number@\"})"}, "@...@"]`)
+	assert.Nil(t, err)
+
+	ok, err := p.MatchStream(strings.NewReader(`[{"payload": "{\"id\": true}"}]`))
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "expected number at path: [0].payload.id")
+}
+
+func TestEmbeddedPatternInvalid(t *testing.T) {
+	tests := []struct {
+		p      string
+		errMsg string
+	}{
+		{`{"a": "@json@(1, 2)"}`, `invalid JSON pattern: invalid embedded JSON pattern "@json@(1, 2)": expected a single JSON argument at path: a`},
+		{`{"a": "@json@({\"b\": \"@expr(value >)@\"})"}`, `invalid JSON pattern: invalid expression "value >": unexpected end of expression at column 8 at path: a.b`},
+	}
+	for _, tt := range tests {
+		_, err := NewDefaultJSONMatcher().Compile(tt.p)
+
+		assert.EqualError(t, err, tt.errMsg)
+	}
+}
+
+func TestEmbeddedPatternLint(t *testing.T) {
+	issues, err := NewDefaultJSONMatcher().Lint(`{"payload": "@json@({\"id\": \"@number@\"})"}`)
+
+	assert.Nil(t, err)
+	assert.Empty(t, issues)
+}
@@ -0,0 +1,79 @@
+package gomatch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An EnumMatcher matches a value equal to one of pattern arguments,
+// e.g. `@enum("ACTIVE", "SUSPENDED", "DELETED")@` or `@enum@(1, 2, 3)`.
+// Arguments may be any JSON scalars: strings, numbers, booleans and null.
+// Numbers are compared by value, so 1.0 matches `@enum(1, 2)@`.
+type EnumMatcher struct {
+	pattern string
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *EnumMatcher) CanMatch(p interface{}) bool {
+	_, ok := m.values(p)
+	return ok
+}
+
+// Match performs value matching against given pattern.
+func (m *EnumMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *EnumMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	values, ok := m.values(p)
+	if !ok {
+		return nil, false
+	}
+	allowed := make(map[string]bool, len(values))
+	strs := make([]string, len(values))
+	for i, e := range values {
+		allowed[canonicalValue(e.value)] = true
+		strs[i] = string(e.arg)
+	}
+	return func(v interface{}) error {
+		if !allowed[canonicalValue(v)] {
+			return fmt.Errorf("expected one of %s", strings.Join(strs, ", "))
+		}
+		return nil
+	}, true
+}
+
+type enumValue struct {
+	arg   patternArg
+	value interface{}
+}
+
+// values returns values given as arguments of pattern p.
+func (m *EnumMatcher) values(p interface{}) ([]enumValue, bool) {
+	parsed, ok := matchPattern(p, m.pattern)
+	if !ok || !parsed.hasArgs || len(parsed.expanders) > 0 {
+		return nil, false
+	}
+	args, err := parseArgs(parsed.args)
+	if err != nil || len(args) == 0 {
+		return nil, false
+	}
+	values := make([]enumValue, len(args))
+	for i, a := range args {
+		v, err := decodeJSON([]byte(a), true)
+		if err != nil {
+			return nil, false
+		}
+		switch v.(type) {
+		case []interface{}, map[string]interface{}:
+			return nil, false
+		}
+		values[i] = enumValue{a, v}
+	}
+	return values, true
+}
+
+// NewEnumMatcher creates EnumMatcher.
+func NewEnumMatcher(pattern string) *EnumMatcher {
+	return &EnumMatcher{pattern}
+}
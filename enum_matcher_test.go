@@ -0,0 +1,56 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var enumMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match string", `@enum("ACTIVE", "SUSPENDED")@`, "SUSPENDED", true, ""},
+	{"Should match arguments given after delimiters", `@enum@("ACTIVE", "SUSPENDED")`, "ACTIVE", true, ""},
+	{"Should match number by value", `@enum(1, 2.5)@`, json.Number("2.50"), true, ""},
+	{"Should match float64", `@enum(1, 2.5)@`, 2.5, true, ""},
+	{"Should match bool and null", `@enum(true, null)@`, nil, true, ""},
+	{"Should not match other value", `@enum("ACTIVE", "SUSPENDED")@`, "DELETED", false, `expected one of "ACTIVE", "SUSPENDED"`},
+	{"Should not match value of other type", `@enum("1", 2)@`, json.Number("1"), false, `expected one of "1", 2`},
+}
+
+func TestEnumMatcher(t *testing.T) {
+	m := NewEnumMatcher("@enum@")
+	for _, tt := range enumMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestEnumMatcherCanMatch(t *testing.T) {
+	m := NewEnumMatcher("@enum@")
+	for _, p := range []string{"@enum@", "@enum()@", `@enum([1])@`, `@enum({"a": 1})@`, `@enum(a)@`, `@enum("a")@.notEmpty()`} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
+
+func TestEnumPattern(t *testing.T) {
+	ok, err := NewDefaultJSONMatcher().Match(`{"status": "@enum(\"ACTIVE\", \"DELETED\")@"}`, `{"status": "NEW"}`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, `expected one of "ACTIVE", "DELETED" at path: status`)
+}
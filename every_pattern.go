@@ -0,0 +1,78 @@
+package gomatch
+
+import "fmt"
+
+const expanderEvery = "every"
+
+// An everyPattern is an "@array@.every(...)" pattern of a compiled pattern. It matches
+// an array of any length when every its element matches the nested pattern:
+//
+//  "@array@.every({\"id\": \"@number@\"})"
+//  "@array@.unique().every(\"@string@\")"
+//
+// Other array expanders have to precede ".every(...)". Mismatches are reported
+// at paths of elements, e.g. "items[41].id". MatchStream matches elements one by one
+// as they are read, unless other expanders need the whole array.
+type everyPattern struct {
+	// array is the pattern without ".every(...)", it is resolved when compiling
+	array    string
+	resolved *valuePattern
+	expected interface{}
+}
+
+func (c *config) parseEveryPattern(s string, parsed *parsedPattern) (nestedPattern, error) {
+	n := len(parsed.expanders)
+	if n == 0 || parsed.expanders[n-1].name != expanderEvery {
+		return nil, nil
+	}
+	array := parsed.head(s, n-1)
+	if vp := c.resolvePattern(array); vp == nil || !isArrayMatcher(vp.matcher) {
+		return nil, nil
+	}
+	args, err := parseArgs(parsed.expanders[n-1].args)
+	if err != nil || len(args) != 1 {
+		return nil, fmt.Errorf(`invalid array pattern "%s": expected a single JSON argument of every()`, s)
+	}
+	nested, err := c.decodeNestedPattern(s, args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &everyPattern{array: array, expected: nested}, nil
+}
+
+func (e *everyPattern) compile(p *CompiledPattern, path []interface{}) error {
+	e.resolved = p.resolvePattern(e.array)
+	var err error
+	e.expected, err = p.compile(e.expected, path)
+	return err
+}
+
+func (e *everyPattern) match(s *matchState, v interface{}) {
+	if err := e.resolved.match(s.p.registry, v); err != nil {
+		s.fail(err)
+		return
+	}
+	actual := v.([]interface{})
+	s.parents = append(s.parents, v)
+	defer s.popParent()
+	for i, a := range actual {
+		if s.done() {
+			return
+		}
+		s.push(i)
+		s.deepMatch(e.expected, a)
+		s.pop()
+	}
+}
+
+// streamed returns true if elements may be matched while they are read by MatchStream.
+// It is true when the array pattern has no expanders, which need the whole array.
+func (e *everyPattern) streamed() bool {
+	m := e.resolved.matcher.(*ArrayMatcher)
+	return e.resolved.pattern == m.pattern && len(e.resolved.expanders) == 0
+}
+
+func isArrayMatcher(m ValueMatcher) bool {
+	_, ok := m.(*ArrayMatcher)
+	return ok
+}
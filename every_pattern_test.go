@@ -0,0 +1,116 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var everyPatternTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match empty array", `{"items": "@array@.every(\"@number@\")"}`, `{"items": []}`, true, ""},
+	{"Should match array of any length", `{"items": "@array@.every(\"@number@\")"}`, `{"items": [1, 2, 3, 4]}`, true, ""},
+	{"Should not match not an array", `{"items": "@array@.every(\"@number@\")"}`, `{"items": {}}`, false, "expected array at path: items"},
+	{
+		"Should report mismatched element",
+		`{"items": "@array@.every({\"id\": \"@number@\"})"}`,
+		`{"items": [{"id": 1}, {"id": 2}, {"id": "bad"}]}`,
+		false,
+		"expected number at path: items[2].id",
+	},
+	{
+		"Should check other expanders",
+		`{"items": "@array@.unique().every(\"@number@\")"}`,
+		`{"items": [1, 1]}`,
+		false,
+		"expected unique elements, element [1] equals [0] at path: items",
+	},
+	{
+		"Should match elements with unbounded objects",
+		`"@array@.every({\"id\": \"@number@\", \"@...@\": \"\"})"`,
+		`[{"id": 1, "name": "a"}, {"id": 2}]`,
+		true,
+		"",
+	},
+	{
+		"Should resolve references of elements",
+		`{"total": "@number@", "items": "@array@.every({\"n\": \"@number@.equals($.total)\"})"}`,
+		`{"total": 2, "items": [{"n": 2}, {"n": 3}]}`,
+		false,
+		"expected number equal to 2 at path: items[1].n",
+	},
+	{"Should report path of root array element", `"@array@.every(\"@string@\")"`, `["a", 1]`, false, "expected string at path: [1]"},
+}
+
+func TestEveryPattern(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range everyPatternTests {
+		ok, err := m.Match(tt.p, tt.v)
+
+		t.Logf(tt.desc)
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestEveryPatternStream(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range everyPatternTests {
+		if strings.Contains(tt.p, "$.") {
+			// references are not supported by MatchStream
+			continue
+		}
+		ok, err := m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		t.Logf(tt.desc)
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestEveryPatternMaxErrors(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithMaxErrors(0))
+
+	ok, err := m.Match(`"@array@.every(\"@number@\")"`, `[1, "a", 2, "b"]`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "2 mismatches: expected number at path: [1]; expected number at path: [3]")
+}
+
+func TestEveryPatternInvalid(t *testing.T) {
+	tests := []struct {
+		p      string
+		errMsg string
+	}{
+		{`{"a": "@array@.every(1, 2)"}`, `invalid JSON pattern: invalid array pattern "@array@.every(1, 2)": expected a single JSON argument of every() at path: a`},
+		{`{"a": "@array@.every({\"b\": \"@expr(value >)@\"})"}`, `invalid JSON pattern: invalid expression "value >": unexpected end of expression at column 8 at path: a.b`},
+	}
+	for _, tt := range tests {
+		_, err := NewDefaultJSONMatcher().Compile(tt.p)
+
+		assert.EqualError(t, err, tt.errMsg)
+	}
+}
+
+func TestEveryPatternLint(t *testing.T) {
+	issues, err := NewDefaultJSONMatcher().Lint(`{"items": "@array@.every({\"id\": \"@number@\"})"}`)
+
+	assert.Nil(t, err)
+	assert.Empty(t, issues)
+}
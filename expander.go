@@ -0,0 +1,113 @@
+package gomatch
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// An expanderFunc builds a check of an expander of a built-in pattern from its arguments,
+// e.g. for ".equals(5)" it returns a function checking if a value equals 5.
+// It returns an error if arguments are invalid.
+type expanderFunc func(args []patternArg) (func(v interface{}) error, error)
+
+// An expanderSet holds expanders supported by a built-in value matcher.
+type expanderSet map[string]expanderFunc
+
+// build parses pattern p having given base pattern and returns checks of all its expanders.
+// It returns false if p has a different base pattern, has arguments or uses unknown expanders.
+func (s expanderSet) build(p interface{}, base string) ([]func(v interface{}) error, bool) {
+	parsed, ok := matchPattern(p, base)
+	if !ok || parsed.hasArgs {
+		return nil, false
+	}
+	checks, err := s.buildChecks(parsed.expanders)
+	return checks, err == nil
+}
+
+// buildChecks returns checks of expanders or an error of the first expander
+// which is unknown or has invalid arguments.
+func (s expanderSet) buildChecks(expanders []patternCall) ([]func(v interface{}) error, error) {
+	checks := make([]func(v interface{}) error, 0, len(expanders))
+	for _, e := range expanders {
+		fn, ok := s[e.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown expander %s()", e.name)
+		}
+		args, err := parseArgs(e.args)
+		if err == nil {
+			var check func(v interface{}) error
+			if check, err = fn(args); err == nil {
+				checks = append(checks, check)
+				continue
+			}
+		}
+		return nil, fmt.Errorf("invalid arguments of %s(): %s", e.name, err.Error())
+	}
+	return checks, nil
+}
+
+// runChecks runs checks built by expanderSet.build and returns the first error.
+func runChecks(checks []func(v interface{}) error, v interface{}) error {
+	for _, check := range checks {
+		if err := check(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// argsCount returns an error if number of arguments is not n.
+func argsCount(args []patternArg, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
+
+// intArg returns value of a non-negative integer argument.
+func intArg(a patternArg) (int, error) {
+	n, err := strconv.Atoi(string(a))
+	if err != nil || n < 0 {
+		return 0, errInvalidArgs
+	}
+	return n, nil
+}
+
+// A patternCompiler is a value matcher able to prepare a pattern once.
+// CompiledPattern keeps the prepared function, so the pattern is not parsed on every match.
+type patternCompiler interface {
+	compilePattern(p interface{}) (func(v interface{}) error, bool)
+}
+
+// An expanderUser is a value matcher of a built-in pattern supporting expanders.
+// Lint uses it to explain why a pattern with expanders cannot be handled.
+type expanderUser interface {
+	patternExpanders() expanderSet
+}
+
+// compile builds checks of pattern p like build and returns a function running them
+// after validate, which checks a value regardless of expanders, e.g. its type.
+func (s expanderSet) compile(p interface{}, base string, validate func(v interface{}) error) (func(v interface{}) error, bool) {
+	checks, ok := s.build(p, base)
+	if !ok {
+		return nil, false
+	}
+	return func(v interface{}) error {
+		if err := validate(v); err != nil {
+			return err
+		}
+		return runChecks(checks, v)
+	}, true
+}
+
+// matchCompiled matches value v with pattern p prepared by compile.
+func matchCompiled(compile func(p interface{}) (func(v interface{}) error, bool), p, v interface{}) (bool, error) {
+	match, ok := compile(p)
+	if !ok {
+		return false, errMatcherNotFound
+	}
+	if err := match(v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,633 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const patternExpr = "expr"
+
+// maxExprDepth limits nesting of expressions to keep parsing and evaluation bounded.
+const maxExprDepth = 64
+
+var errExprDivByZero = errors.New("division by zero")
+
+// An exprPattern is an "@expr(...)@" pattern of a compiled pattern.
+//
+// The expression is evaluated for the matched value and has to return true.
+// It may use:
+//
+// - value - the matched value
+//
+// - references to actual JSON: "$.total" (from the root) and "$$..price" (a sibling)
+//
+// - literals: numbers, strings in single or double quotes, true, false and null
+//
+// - operators: ||, &&, !, ==, !=, <, <=, >, >=, +, -, *, /, % and parentheses
+//
+// - functions: len, abs, min, max, lower, upper, contains and matches
+//
+// Numbers are compared and calculated exactly, e.g. "@expr(value == $$.price * $$.quantity)@".
+// Expressions can't call any other code, so patterns from untrusted sources are safe to evaluate.
+type exprPattern struct {
+	src  string
+	root exprNode
+}
+
+// parseExprPattern parses p if it is an "@expr(...)@" pattern.
+// It returns nil if p is not such pattern.
+func parseExprPattern(p string) (*exprPattern, error) {
+	parsed, ok := parsePattern(p)
+	if !ok || parsed.name != patternExpr || !parsed.hasArgs || len(parsed.expanders) > 0 {
+		return nil, nil
+	}
+	root, err := parseExpr(parsed.args)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid expression "%s": %s`, parsed.args, err.Error())
+	}
+	return &exprPattern{parsed.args, root}, nil
+}
+
+func (e *exprPattern) match(s *matchState, v interface{}) error {
+	res, err := e.root.eval(&exprContext{s, v})
+	if err != nil {
+		return fmt.Errorf(`expression "%s" failed: %s`, e.src, err.Error())
+	}
+	if res != true {
+		return fmt.Errorf(`expression "%s" is not satisfied`, e.src)
+	}
+	return nil
+}
+
+type exprContext struct {
+	s     *matchState
+	value interface{}
+}
+
+type exprNode interface {
+	eval(c *exprContext) (interface{}, error)
+}
+
+type exprLiteral struct {
+	v interface{}
+}
+
+func (n *exprLiteral) eval(c *exprContext) (interface{}, error) {
+	return n.v, nil
+}
+
+type exprValue struct{}
+
+func (n *exprValue) eval(c *exprContext) (interface{}, error) {
+	return toExprValue(c.value)
+}
+
+type exprRef struct {
+	ref *reference
+}
+
+func (n *exprRef) eval(c *exprContext) (interface{}, error) {
+	v, err := n.ref.resolve(c.s)
+	if err != nil {
+		return nil, err
+	}
+	return toExprValue(v)
+}
+
+type exprUnary struct {
+	op string
+	x  exprNode
+}
+
+func (n *exprUnary) eval(c *exprContext) (interface{}, error) {
+	x, err := n.x.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		b, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator ! expects bool, got %s", exprType(x))
+		}
+		return !b, nil
+	default:
+		r, ok := x.(*big.Rat)
+		if !ok {
+			return nil, fmt.Errorf("operator - expects number, got %s", exprType(x))
+		}
+		return new(big.Rat).Neg(r), nil
+	}
+}
+
+type exprBinary struct {
+	op   string
+	x, y exprNode
+}
+
+func (n *exprBinary) eval(c *exprContext) (interface{}, error) {
+	x, err := n.x.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" || n.op == "||" {
+		return n.evalLogical(c, x)
+	}
+	y, err := n.y.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return exprEqual(x, y), nil
+	case "!=":
+		return !exprEqual(x, y), nil
+	case "<", "<=", ">", ">=":
+		return n.evalCompare(x, y)
+	case "+":
+		if xs, ok := x.(string); ok {
+			if ys, ok := y.(string); ok {
+				return xs + ys, nil
+			}
+		}
+	}
+	return n.evalArithmetic(x, y)
+}
+
+func (n *exprBinary) evalLogical(c *exprContext, x interface{}) (interface{}, error) {
+	xb, ok := x.(bool)
+	if !ok {
+		return nil, fmt.Errorf("operator %s expects bool, got %s", n.op, exprType(x))
+	}
+	if n.op == "&&" && !xb || n.op == "||" && xb {
+		return xb, nil
+	}
+	y, err := n.y.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	yb, ok := y.(bool)
+	if !ok {
+		return nil, fmt.Errorf("operator %s expects bool, got %s", n.op, exprType(y))
+	}
+	return yb, nil
+}
+
+func (n *exprBinary) evalCompare(x, y interface{}) (interface{}, error) {
+	var c int
+	switch xv := x.(type) {
+	case *big.Rat:
+		yv, ok := y.(*big.Rat)
+		if !ok {
+			return nil, fmt.Errorf("can't compare number with %s", exprType(y))
+		}
+		c = xv.Cmp(yv)
+	case string:
+		yv, ok := y.(string)
+		if !ok {
+			return nil, fmt.Errorf("can't compare string with %s", exprType(y))
+		}
+		c = strings.Compare(xv, yv)
+	default:
+		return nil, fmt.Errorf("operator %s expects numbers or strings, got %s", n.op, exprType(x))
+	}
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	}
+	return c >= 0, nil
+}
+
+func (n *exprBinary) evalArithmetic(x, y interface{}) (interface{}, error) {
+	xr, ok := x.(*big.Rat)
+	if !ok {
+		return nil, fmt.Errorf("operator %s expects numbers, got %s", n.op, exprType(x))
+	}
+	yr, ok := y.(*big.Rat)
+	if !ok {
+		return nil, fmt.Errorf("operator %s expects numbers, got %s", n.op, exprType(y))
+	}
+	switch n.op {
+	case "+":
+		return new(big.Rat).Add(xr, yr), nil
+	case "-":
+		return new(big.Rat).Sub(xr, yr), nil
+	case "*":
+		return new(big.Rat).Mul(xr, yr), nil
+	case "/":
+		if yr.Sign() == 0 {
+			return nil, errExprDivByZero
+		}
+		return new(big.Rat).Quo(xr, yr), nil
+	}
+	if !xr.IsInt() || !yr.IsInt() {
+		return nil, errors.New("operator % expects integers")
+	}
+	if yr.Sign() == 0 {
+		return nil, errExprDivByZero
+	}
+	return new(big.Rat).SetInt(new(big.Int).Rem(xr.Num(), yr.Num())), nil
+}
+
+type exprCall struct {
+	name string
+	args []exprNode
+}
+
+type exprFunc struct {
+	minArgs, maxArgs int
+	fn               func(args []interface{}) (interface{}, error)
+}
+
+var exprFuncs map[string]exprFunc
+
+func init() {
+	exprFuncs = map[string]exprFunc{
+		"len":      {1, 1, exprLen},
+		"abs":      {1, 1, exprAbs},
+		"min":      {1, -1, exprMinMax(-1)},
+		"max":      {1, -1, exprMinMax(1)},
+		"lower":    {1, 1, exprStringFunc(strings.ToLower)},
+		"upper":    {1, 1, exprStringFunc(strings.ToUpper)},
+		"contains": {2, 2, exprContains},
+		"matches":  {2, 2, exprMatches},
+	}
+}
+
+func (n *exprCall) eval(c *exprContext) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(c)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := exprFuncs[n.name].fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", n.name, err.Error())
+	}
+	return v, nil
+}
+
+func exprLen(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return big.NewRat(int64(utf8.RuneCountInString(v)), 1), nil
+	case []interface{}:
+		return big.NewRat(int64(len(v)), 1), nil
+	case map[string]interface{}:
+		return big.NewRat(int64(len(v)), 1), nil
+	}
+	return nil, fmt.Errorf("expects string, array or object, got %s", exprType(args[0]))
+}
+
+func exprAbs(args []interface{}) (interface{}, error) {
+	r, ok := args[0].(*big.Rat)
+	if !ok {
+		return nil, fmt.Errorf("expects number, got %s", exprType(args[0]))
+	}
+	return new(big.Rat).Abs(r), nil
+}
+
+func exprMinMax(sign int) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		var res *big.Rat
+		for _, a := range args {
+			r, ok := a.(*big.Rat)
+			if !ok {
+				return nil, fmt.Errorf("expects numbers, got %s", exprType(a))
+			}
+			if res == nil || r.Cmp(res) == sign {
+				res = r
+			}
+		}
+		return res, nil
+	}
+}
+
+func exprStringFunc(fn func(string) string) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expects string, got %s", exprType(args[0]))
+		}
+		return fn(s), nil
+	}
+}
+
+func exprContains(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		sub, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("expects string to look for, got %s", exprType(args[1]))
+		}
+		return strings.Contains(v, sub), nil
+	case []interface{}:
+		for _, e := range v {
+			ev, err := toExprValue(e)
+			if err != nil {
+				return nil, err
+			}
+			if exprEqual(ev, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return nil, fmt.Errorf("expects string or array, got %s", exprType(args[0]))
+}
+
+func exprMatches(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("expects string, got %s", exprType(args[0]))
+	}
+	var re *regexp.Regexp
+	switch expr := args[1].(type) {
+	case *regexp.Regexp:
+		re = expr
+	case string:
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expects regular expression string, got %s", exprType(args[1]))
+	}
+	return re.MatchString(s), nil
+}
+
+// toExprValue converts a value of JSON to a value used by expressions.
+// Numbers are converted to *big.Rat.
+func toExprValue(v interface{}) (interface{}, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return parseRat(n.String())
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, errNumberRange
+		}
+		return r, nil
+	}
+	return v, nil
+}
+
+func exprEqual(x, y interface{}) bool {
+	if xr, ok := x.(*big.Rat); ok {
+		yr, ok := y.(*big.Rat)
+		return ok && xr.Cmp(yr) == 0
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func exprType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case *big.Rat:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// exprParser is a precedence climbing parser of expressions.
+type exprParser struct {
+	s     string
+	pos   int
+	depth int
+}
+
+var exprBinaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+func parseExpr(s string) (exprNode, error) {
+	p := &exprParser{s: s}
+	n, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return n, nil
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at column %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) > -1 {
+		p.pos++
+	}
+}
+
+// binaryOp returns binary operator at current position.
+func (p *exprParser) binaryOp() string {
+	p.skipSpaces()
+	for _, op := range []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%"} {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *exprParser) parseBinary(minPrecedence int) (exprNode, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.binaryOp()
+		precedence, ok := exprBinaryPrecedence[op]
+		if !ok || precedence < minPrecedence {
+			return x, nil
+		}
+		p.pos += len(op)
+		y, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &exprBinary{op, x, y}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExprDepth {
+		return nil, p.errorf("expression is too deeply nested")
+	}
+	p.skipSpaces()
+	if p.pos < len(p.s) && (p.s[p.pos] == '!' || p.s[p.pos] == '-') {
+		op := p.s[p.pos : p.pos+1]
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{op, x}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+	c := p.s[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		x, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.s) || p.s[p.pos] != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return x, nil
+	case c == '$':
+		ref, end, err := parseReference(p.s, p.pos)
+		if err != nil {
+			return nil, err
+		}
+		p.pos = end
+		return &exprRef{ref}, nil
+	case c == '"' || c == '\'':
+		return p.parseString(c)
+	case c >= '0' && c <= '9':
+		return p.parseNumber()
+	case isIdentByte(c):
+		return p.parseIdent()
+	}
+	return nil, p.errorf("unexpected %q", string(c))
+}
+
+func (p *exprParser) parseString(quote byte) (exprNode, error) {
+	end := closingQuoteChar(p.s, p.pos, quote)
+	if end < 0 {
+		return nil, p.errorf("unterminated string")
+	}
+	raw := p.s[p.pos+1 : end]
+	if quote == '\'' {
+		raw = strings.Replace(strings.Replace(raw, `\'`, `'`, -1), `"`, `\"`, -1)
+	}
+	var s string
+	if err := json.Unmarshal([]byte(`"`+raw+`"`), &s); err != nil {
+		return nil, p.errorf("invalid string")
+	}
+	p.pos = end + 1
+	return &exprLiteral{s}, nil
+}
+
+func (p *exprParser) parseNumber() (exprNode, error) {
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("0123456789.eE", p.s[p.pos]) > -1 {
+		if (p.s[p.pos] == 'e' || p.s[p.pos] == 'E') && p.pos+1 < len(p.s) && (p.s[p.pos+1] == '-' || p.s[p.pos+1] == '+') {
+			p.pos++
+		}
+		p.pos++
+	}
+	r, err := parseRat(p.s[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%s", err.Error())
+	}
+	return &exprLiteral{r}, nil
+}
+
+func (p *exprParser) parseIdent() (exprNode, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isIdentByte(p.s[p.pos]) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	switch name {
+	case "value":
+		return &exprValue{}, nil
+	case "true":
+		return &exprLiteral{true}, nil
+	case "false":
+		return &exprLiteral{false}, nil
+	case "null":
+		return &exprLiteral{nil}, nil
+	}
+	f, ok := exprFuncs[name]
+	p.skipSpaces()
+	if !ok || p.pos >= len(p.s) || p.s[p.pos] != '(' {
+		p.pos = start
+		return nil, p.errorf("unknown identifier %q", name)
+	}
+	p.pos++
+	call := &exprCall{name: name}
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == ')' {
+		p.pos++
+	} else {
+		for {
+			arg, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			p.skipSpaces()
+			if p.pos < len(p.s) && p.s[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			if p.pos < len(p.s) && p.s[p.pos] == ')' {
+				p.pos++
+				break
+			}
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+	if len(call.args) < f.minArgs || f.maxArgs > -1 && len(call.args) > f.maxArgs {
+		return nil, fmt.Errorf("wrong number of arguments of %s", name)
+	}
+	if name == "matches" {
+		// a literal regular expression is compiled once, other ones on evaluation
+		if lit, ok := call.args[1].(*exprLiteral); ok {
+			if expr, ok := lit.v.(string); ok {
+				re, err := regexp.Compile(expr)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regular expression of matches: %s", err.Error())
+				}
+				call.args[1] = &exprLiteral{re}
+			}
+		}
+	}
+	return call, nil
+}
@@ -0,0 +1,104 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var exprTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match value satisfying expression", `{"age": "@expr(value >= 18)@"}`, `{"age": 21}`, true, ""},
+	{"Should not match value not satisfying expression", `{"age": "@expr(value >= 18)@"}`, `{"age": 17}`, false, `expression "value >= 18" is not satisfied at path: age`},
+	{"Should calculate numbers exactly", `{"sum": "@expr(value == 0.3)@"}`, `{"sum": 0.30}`, true, ""},
+	{"Should calculate with siblings", `{"price": "@number@", "quantity": "@number@", "total": "@expr(value == $$.price * $$.quantity)@"}`, `{"price": 0.1, "quantity": 3, "total": 0.3}`, true, ""},
+	{"Should report mismatch with siblings", `{"price": "@number@", "quantity": "@number@", "total": "@expr(value == $$.price * $$.quantity)@"}`, `{"price": 0.1, "quantity": 3, "total": 0.4}`, false, `expression "value == $$.price * $$.quantity" is not satisfied at path: total`},
+	{"Should refer to root", `{"total": "@number@", "items": [{"price": "@expr(value <= $.total)@"}]}`, `{"total": 10, "items": [{"price": 5}]}`, true, ""},
+	{"Should refer to elements with index", `{"items": [1, 2], "first": "@expr(value == $.items[0])@"}`, `{"items": [1, 2], "first": 1}`, true, ""},
+	{"Should refer to keys in brackets", `{"a b": 1, "c": "@expr(value == $$[\"a b\"])@"}`, `{"a b": 1, "c": 1}`, true, ""},
+	{"Should compare strings", `{"a": "@expr(value != '' && lower(value) == 'abc')@"}`, `{"a": "ABC"}`, true, ""},
+	{"Should concatenate strings", `{"a": "x", "b": "@expr(value == $$.a + '-1')@"}`, `{"a": "x", "b": "x-1"}`, true, ""},
+	{"Should call functions", `["@expr(len(value) == 2 && contains(value, 'b'))@"]`, `[["a", "b"]]`, true, ""},
+	{"Should call min and max", `["@expr(min(value, 10) == value && max(1, 2, 3) == 3)@"]`, `[5]`, true, ""},
+	{"Should call abs", `["@expr(abs(value) == 2)@"]`, `[-2]`, true, ""},
+	{"Should call matches", `["@expr(matches(value, '^[a-z]+$'))@"]`, `["abc"]`, true, ""},
+	{"Should call matches with referenced expression", `{"re": "@string@", "a": "@expr(matches(value, $$.re))@"}`, `{"re": "^[a-z]+$", "a": "ABC"}`, false, `expression "matches(value, $$.re)" is not satisfied at path: a`},
+	{"Should evaluate operators precedence", `["@expr(1 + 2 * 3 == 7 && !(7 % 4 != 3) || false)@"]`, `[1]`, true, ""},
+	{"Should compare with null", `["@expr(value == null)@"]`, `[null]`, true, ""},
+	{"Should report type errors", `{"a": "@expr(value > 1)@"}`, `{"a": "x"}`, false, `expression "value > 1" failed: can't compare string with number at path: a`},
+	{"Should report missing references", `{"a": "@expr(value == $$.b)@"}`, `{"a": 1}`, false, `expression "value == $$.b" failed: reference "$$.b" not found at path: a`},
+	{"Should report division by zero", `["@expr(value / 0 == 1)@"]`, `[1]`, false, `expression "value / 0 == 1" failed: division by zero at path: [0]`},
+	{"Should report expression not returning bool", `["@expr(value)@"]`, `[1]`, false, `expression "value" is not satisfied at path: [0]`},
+}
+
+func TestExprPattern(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range exprTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestExprPatternInvalid(t *testing.T) {
+	tests := []struct {
+		desc   string
+		p      string
+		errMsg string
+	}{
+		{"Should report unknown identifier", `{"a": "@expr(valu > 1)@"}`, `invalid JSON pattern: invalid expression "valu > 1": unknown identifier "valu" at column 1 at path: a`},
+		{"Should report missing operand", `["@expr(value >)@"]`, `invalid JSON pattern: invalid expression "value >": unexpected end of expression at column 8 at path: [0]`},
+		{"Should report trailing tokens", `"@expr(value 1)@"`, `invalid JSON pattern: invalid expression "value 1": unexpected "1" at column 7`},
+		{"Should report wrong number of arguments", `"@expr(len(value, 1) > 1)@"`, `invalid JSON pattern: invalid expression "len(value, 1) > 1": wrong number of arguments of len`},
+		{"Should report invalid regular expression", `"@expr(matches(value, '['))@"`, "invalid JSON pattern: invalid expression \"matches(value, '[')\": invalid regular expression of matches: error parsing regexp: missing closing ]: `[`"},
+		{"Should report too large numbers", `"@expr(value > 1e100000)@"`, `invalid JSON pattern: invalid expression "value > 1e100000": number out of range at column 9`},
+		{"Should report deep nesting", `"@expr(` + strings.Repeat("!", 100) + `true)@"`, `invalid JSON pattern: invalid expression "` + strings.Repeat("!", 100) + `true": expression is too deeply nested at column 65`},
+	}
+	m := NewDefaultJSONMatcher()
+	for _, tt := range tests {
+		t.Logf(tt.desc)
+		_, err := m.Compile(tt.p)
+
+		assert.EqualError(t, err, tt.errMsg)
+	}
+}
+
+func TestExprPatternUnorderedArrays(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithUnorderedArrays())
+
+	ok, err := m.Match(`["@expr(value > 2)@", 1]`, `[1, 3]`)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestExprPatternStream(t *testing.T) {
+	p, err := NewDefaultJSONMatcher().Compile(`{"a": "@expr(value > 2)@", "b": "@expr(value == $$.a)@"}`)
+	assert.Nil(t, err)
+
+	ok, err := p.MatchStream(strings.NewReader(`{"a": 3, "b": 3}`))
+	assert.EqualError(t, err, `expression "value == $$.a" failed: references are not supported when matching a stream at path: b`)
+	assert.False(t, ok)
+
+	ok, err = p.MatchStream(strings.NewReader(`{"a": 1, "b": 3}`))
+	assert.EqualError(t, err, `expression "value > 2" is not satisfied at path: a`)
+	assert.False(t, ok)
+}
+
+func TestExprPatternLint(t *testing.T) {
+	issues, err := NewDefaultJSONMatcher().Lint(`{"a": "@expr(value>1)@"}`)
+	assert.Nil(t, err)
+	assert.Empty(t, issues)
+}
@@ -0,0 +1,35 @@
+package gomatch
+
+// A ValueMatcherFunc checks value v. It returns an error describing a mismatch.
+type ValueMatcherFunc func(v interface{}) error
+
+// A FuncMatcher is an adapter which allows to use a function as a ValueMatcher.
+type FuncMatcher struct {
+	pattern string
+	fn      ValueMatcherFunc
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *FuncMatcher) CanMatch(p interface{}) bool {
+	return isPattern(p, m.pattern)
+}
+
+// Match calls the function with value v.
+func (m *FuncMatcher) Match(p, v interface{}) (bool, error) {
+	if err := m.fn(v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NewFuncMatcher creates FuncMatcher handling given pattern with function fn.
+//
+//  m := gomatch.NewFuncMatcher("@positive@", func(v interface{}) error {
+//  	if n, ok := v.(json.Number); ok && !strings.HasPrefix(n.String(), "-") {
+//  		return nil
+//  	}
+//  	return errors.New("expected positive number")
+//  })
+func NewFuncMatcher(pattern string, fn ValueMatcherFunc) *FuncMatcher {
+	return &FuncMatcher{pattern, fn}
+}
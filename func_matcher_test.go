@@ -0,0 +1,29 @@
+package gomatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMatcher(t *testing.T) {
+	pattern := "@pattern@"
+	m := NewFuncMatcher(pattern, func(v interface{}) error {
+		if v == "ok" {
+			return nil
+		}
+		return errors.New("expected ok")
+	})
+
+	assert.True(t, m.CanMatch(pattern), "expected to support pattern")
+	assert.False(t, m.CanMatch("@other@"), "not expected to support other pattern")
+
+	ok, err := m.Match(pattern, "ok")
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, err = m.Match(pattern, "not ok")
+	assert.False(t, ok)
+	assert.EqualError(t, err, "expected ok")
+}
@@ -0,0 +1,59 @@
+package gomatch
+
+import (
+	"errors"
+	"strings"
+)
+
+var errNotHostname = errors.New("expected hostname")
+
+// Length limits of a hostname without a trailing dot and of its labels.
+const (
+	maxHostnameLength      = 253
+	maxHostnameLabelLength = 63
+)
+
+// A HostnameMatcher matches hostnames as defined by RFC 1123, e.g. "api.example.com".
+// IP addresses are not hostnames, use IPMatcher to match them.
+type HostnameMatcher struct {
+	pattern string
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *HostnameMatcher) CanMatch(p interface{}) bool {
+	return isPattern(p, m.pattern)
+}
+
+// Match performs value matching against given pattern.
+func (m *HostnameMatcher) Match(p, v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok || !isHostname(s) {
+		return false, errNotHostname
+	}
+	return true, nil
+}
+
+// NewHostnameMatcher creates HostnameMatcher.
+func NewHostnameMatcher(pattern string) *HostnameMatcher {
+	return &HostnameMatcher{pattern}
+}
+
+func isHostname(s string) bool {
+	if s == "" || len(s) > maxHostnameLength {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	for _, label := range labels {
+		if label == "" || len(label) > maxHostnameLabelLength ||
+			strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; c != '-' && (c == '_' || !isIdentByte(c)) {
+				return false
+			}
+		}
+	}
+	// the top-level domain can't be numeric, so IPv4 addresses are not hostnames
+	return !isDigits(labels[len(labels)-1])
+}
@@ -0,0 +1,45 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var hostnameMatcherTests = []struct {
+	desc   string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match hostname", "api.example.com", true, ""},
+	{"Should match hostname without dot", "localhost", true, ""},
+	{"Should match hostname with digits and hyphens", "xn--bcher-kva.example-1.com", true, ""},
+	{"Should not match IPv4 address", "192.168.0.1", false, "expected hostname"},
+	{"Should not match label starting with hyphen", "-api.example.com", false, "expected hostname"},
+	{"Should not match empty label", "api..example.com", false, "expected hostname"},
+	{"Should not match underscore", "my_host.example.com", false, "expected hostname"},
+	{"Should not match too long label", "a123456789012345678901234567890123456789012345678901234567890123.com", false, "expected hostname"},
+	{"Should not match number", 1., false, "expected hostname"},
+}
+
+func TestHostnameMatcher(t *testing.T) {
+	pattern := "@pattern@"
+
+	for _, tt := range hostnameMatcherTests {
+		m := NewHostnameMatcher(pattern)
+		assert.True(t, m.CanMatch(pattern), "expected to support pattern")
+
+		t.Logf(tt.desc)
+
+		ok, err := m.Match(pattern, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package gomatch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A pathSelector selects values of actual JSON by their paths, see WithIgnoredPaths.
+type pathSelector struct {
+	src      string
+	segments []selectorSegment
+}
+
+type segmentKind int
+
+const (
+	// segmentKey matches a key or an index
+	segmentKey segmentKind = iota
+	// segmentAny matches any key or index, e.g. "*" or "[*]"
+	segmentAny
+	// segmentDescendant matches any number of levels, e.g. ".." of "$..id"
+	segmentDescendant
+	// segmentPointer matches a key or an index given by a JSON Pointer token
+	segmentPointer
+)
+
+type selectorSegment struct {
+	kind segmentKind
+	key  interface{}
+}
+
+func (seg selectorSegment) matches(key interface{}) bool {
+	switch seg.kind {
+	case segmentAny:
+		return true
+	case segmentPointer:
+		if i, ok := key.(int); ok {
+			return seg.key == strconv.Itoa(i)
+		}
+	}
+	return seg.key == key
+}
+
+// parsePathSelector parses JSONPath, e.g. "$.items[*].updated_at",
+// or JSON Pointer, e.g. "/items/0/updated_at".
+func parsePathSelector(s string) (*pathSelector, error) {
+	switch {
+	case s == "" || strings.HasPrefix(s, "/"):
+		return parseJSONPointer(s)
+	case strings.HasPrefix(s, "$"):
+		return parseJSONPath(s)
+	}
+	return nil, fmt.Errorf(`invalid path "%s": expected JSONPath starting with "$" or JSON Pointer starting with "/"`, s)
+}
+
+// parseJSONPointer parses JSON Pointer as defined by RFC 6901.
+func parseJSONPointer(s string) (*pathSelector, error) {
+	sel := &pathSelector{src: s}
+	if s == "" {
+		return sel, nil
+	}
+	for _, token := range strings.Split(s[1:], "/") {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, fmt.Errorf(`invalid path "%s": invalid escape in "%s"`, s, token)
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		sel.segments = append(sel.segments, selectorSegment{segmentPointer, token})
+	}
+	return sel, nil
+}
+
+// parseJSONPath parses JSONPath having keys (".key", "['key']", "[\"key\"]"),
+// indexes ("[0]"), wildcards (".*", "[*]") and descendants ("..key").
+func parseJSONPath(s string) (*pathSelector, error) {
+	sel := &pathSelector{src: s}
+	for i := 1; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], ".."):
+			sel.segments = append(sel.segments, selectorSegment{kind: segmentDescendant})
+			i += 2
+			if i < len(s) && s[i] == '[' {
+				continue
+			}
+			i--
+			fallthrough
+		case s[i] == '.':
+			j := i + 1
+			if j < len(s) && s[j] == '*' {
+				sel.segments = append(sel.segments, selectorSegment{kind: segmentAny})
+				i = j + 1
+				continue
+			}
+			for j < len(s) && (isIdentByte(s[j]) || s[j] == '-') {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf(`invalid path "%s": expected key after '.' at %d`, s, i)
+			}
+			sel.segments = append(sel.segments, selectorSegment{segmentKey, s[i+1 : j]})
+			i = j
+		case s[i] == '[':
+			end := closingBracket(s, i)
+			if end < 0 {
+				return nil, fmt.Errorf(`invalid path "%s": unclosed '[' at %d`, s, i)
+			}
+			seg, err := parseBracketSegment(s[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf(`invalid path "%s": %s`, s, err.Error())
+			}
+			sel.segments = append(sel.segments, seg)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf(`invalid path "%s": unexpected '%c' at %d`, s, s[i], i)
+		}
+	}
+	return sel, nil
+}
+
+// parseBracketSegment parses a wildcard, an index or a key in single or double quotes given in brackets.
+func parseBracketSegment(s string) (selectorSegment, error) {
+	if s == "*" {
+		return selectorSegment{kind: segmentAny}, nil
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return selectorSegment{segmentKey, s[1 : len(s)-1]}, nil
+	}
+	key, err := parseSelector(s)
+	if err != nil {
+		return selectorSegment{}, err
+	}
+	return selectorSegment{segmentKey, key}, nil
+}
+
+// matches checks if the selector selects a value at given path.
+func (sel *pathSelector) matches(path []interface{}) bool {
+	return matchSegments(sel.segments, path)
+}
+
+func matchSegments(segments []selectorSegment, path []interface{}) bool {
+	if len(segments) == 0 {
+		return len(path) == 0
+	}
+	if segments[0].kind == segmentDescendant {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(segments[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	return len(path) > 0 && segments[0].matches(path[0]) && matchSegments(segments[1:], path[1:])
+}
+
+// compileIgnoredPaths parses selectors given with WithIgnoredPaths.
+func (p *CompiledPattern) compileIgnoredPaths() error {
+	for _, s := range p.ignoredPaths {
+		sel, err := parsePathSelector(s)
+		if err != nil {
+			return fmt.Errorf("%s: %s", errInvalidJSONPattern.Error(), err.Error())
+		}
+		p.ignored = append(p.ignored, sel)
+	}
+	return nil
+}
+
+// ignored checks if the value at current path is ignored.
+func (s *matchState) ignored() bool {
+	for _, sel := range s.p.ignored {
+		if sel.matches(s.path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredKey checks if the value of key k of the current object is ignored.
+func (s *matchState) ignoredKey(k string) bool {
+	if len(s.p.ignored) == 0 {
+		return false
+	}
+	s.push(k)
+	defer s.pop()
+	return s.ignored()
+}
@@ -0,0 +1,110 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePathSelector(t *testing.T) {
+	tests := []struct {
+		s        string
+		path     []interface{}
+		selected bool
+	}{
+		{"$", nil, true},
+		{"$.a.b-c", []interface{}{"a", "b-c"}, true},
+		{"$.a", []interface{}{"a", "b"}, false},
+		{"$.items[*].updated_at", []interface{}{"items", 3, "updated_at"}, true},
+		{"$.items[1]", []interface{}{"items", 0}, false},
+		{"$.*.id", []interface{}{"user", "id"}, true},
+		{`$['a.b']["c d"]`, []interface{}{"a.b", "c d"}, true},
+		{"$..id", []interface{}{"id"}, true},
+		{"$..id", []interface{}{"items", 0, "user", "id"}, true},
+		{"$..[0]", []interface{}{"a", "b", 0}, true},
+		{"$.a..id", []interface{}{"b", "id"}, false},
+		{"", nil, true},
+		{"/items/0/updated_at", []interface{}{"items", 0, "updated_at"}, true},
+		{"/items/0", []interface{}{"items", "0"}, true},
+		{"/a~1b/c~0d", []interface{}{"a/b", "c~d"}, true},
+		{"/a", []interface{}{"a", "b"}, false},
+	}
+	for _, tt := range tests {
+		t.Logf(tt.s)
+		sel, err := parsePathSelector(tt.s)
+
+		if assert.Nil(t, err) {
+			assert.Equal(t, tt.selected, sel.matches(tt.path))
+		}
+	}
+}
+
+func TestParsePathSelectorInvalid(t *testing.T) {
+	for _, s := range []string{"a", "$.", "$..", "$[", "$[a]", "$a", "/~2"} {
+		t.Logf(s)
+		_, err := parsePathSelector(s)
+
+		assert.NotNil(t, err)
+	}
+}
+
+var ignoredPathsTests = []struct {
+	desc   string
+	paths  []string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should ignore different value", []string{"$.updated_at"}, `{"id": 1, "updated_at": "2020"}`, `{"id": 1, "updated_at": "2021"}`, true, ""},
+	{"Should ignore missing key", []string{"$.updated_at"}, `{"id": 1, "updated_at": "2020"}`, `{"id": 1}`, true, ""},
+	{"Should ignore unexpected key", []string{"$.request_id"}, `{"id": 1}`, `{"id": 1, "request_id": "x"}`, true, ""},
+	{"Should ignore values in all elements", []string{"$.items[*].updated_at"}, `{"items": [{"id": 1, "updated_at": 1}, {"id": 2, "updated_at": 2}]}`, `{"items": [{"id": 1, "updated_at": 3}, {"id": 2}]}`, true, ""},
+	{"Should still match other values", []string{"$.items[*].updated_at"}, `{"items": [{"id": 1, "updated_at": 1}]}`, `{"items": [{"id": 2, "updated_at": 1}]}`, false, "values are not equal at path: items[0].id"},
+	{"Should ignore values at any level", []string{"$..request_id"}, `{"a": {"b": [{"request_id": 1}]}}`, `{"a": {"b": [{"request_id": 2}]}, "request_id": 3}`, true, ""},
+	{"Should ignore values by JSON Pointer", []string{"/meta"}, `{"id": 1, "meta": {"a": 1}}`, `{"id": 1, "meta": [1]}`, true, ""},
+	{"Should ignore whole arrays", []string{"$.tags"}, `{"tags": ["a"]}`, `{"tags": ["b", "c"]}`, true, ""},
+}
+
+func TestIgnoredPaths(t *testing.T) {
+	for _, tt := range ignoredPathsTests {
+		t.Logf(tt.desc)
+		m := NewDefaultJSONMatcher(WithIgnoredPaths(tt.paths...))
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+
+		ok, err = m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestIgnoredPathsUnorderedArrays(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithUnorderedArrays(), WithIgnoredPaths("$[*].updated_at"))
+
+	ok, err := m.Match(`[{"id": 1, "updated_at": 1}, {"id": 2, "updated_at": 2}]`, `[{"id": 2, "updated_at": 5}, {"id": 1, "updated_at": 6}]`)
+
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestIgnoredPathsInvalid(t *testing.T) {
+	_, err := NewDefaultJSONMatcher(WithIgnoredPaths("$.items[")).Compile(`{}`)
+
+	assert.EqualError(t, err, `invalid JSON pattern: invalid path "$.items[": unclosed '[' at 7`)
+}
@@ -0,0 +1,65 @@
+package gomatch
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+var (
+	errNotIP   = errors.New("expected IP address")
+	errNotIPv4 = errors.New("expected IPv4 address")
+	errNotIPv6 = errors.New("expected IPv6 address")
+)
+
+// An IPMatcher matches IP addresses in their text form, e.g. "192.168.0.1" or "2001:db8::1".
+// It may be restricted to IPv4 or IPv6 addresses, see NewIPv4Matcher and NewIPv6Matcher.
+type IPMatcher struct {
+	pattern string
+	version int
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *IPMatcher) CanMatch(p interface{}) bool {
+	return isPattern(p, m.pattern)
+}
+
+// Match performs value matching against given pattern.
+func (m *IPMatcher) Match(p, v interface{}) (bool, error) {
+	err := errNotIP
+	switch m.version {
+	case 4:
+		err = errNotIPv4
+	case 6:
+		err = errNotIPv6
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return false, err
+	}
+	// IPv4-mapped IPv6 addresses like "::ffff:1.2.3.4" are IPv6 in their text form
+	isV4 := ip.To4() != nil && !strings.Contains(s, ":")
+	if m.version == 4 && !isV4 || m.version == 6 && isV4 {
+		return false, err
+	}
+	return true, nil
+}
+
+// NewIPMatcher creates IPMatcher matching both IPv4 and IPv6 addresses.
+func NewIPMatcher(pattern string) *IPMatcher {
+	return &IPMatcher{pattern, 0}
+}
+
+// NewIPv4Matcher creates IPMatcher matching IPv4 addresses.
+func NewIPv4Matcher(pattern string) *IPMatcher {
+	return &IPMatcher{pattern, 4}
+}
+
+// NewIPv6Matcher creates IPMatcher matching IPv6 addresses.
+func NewIPv6Matcher(pattern string) *IPMatcher {
+	return &IPMatcher{pattern, 6}
+}
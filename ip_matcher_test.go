@@ -0,0 +1,47 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ipMatcherTests = []struct {
+	desc    string
+	matcher *IPMatcher
+	v       interface{}
+	ok      bool
+	errMsg  string
+}{
+	{"Should match IPv4 address", NewIPMatcher("@pattern@"), "192.168.0.1", true, ""},
+	{"Should match IPv6 address", NewIPMatcher("@pattern@"), "2001:db8::1", true, ""},
+	{"Should not match invalid address", NewIPMatcher("@pattern@"), "192.168.0.256", false, "expected IP address"},
+	{"Should not match number", NewIPMatcher("@pattern@"), 1., false, "expected IP address"},
+	{"Should match IPv4 address only", NewIPv4Matcher("@pattern@"), "10.0.0.1", true, ""},
+	{"Should not match IPv6 address as IPv4", NewIPv4Matcher("@pattern@"), "2001:db8::1", false, "expected IPv4 address"},
+	{"Should not match IPv4-mapped address as IPv4", NewIPv4Matcher("@pattern@"), "::ffff:10.0.0.1", false, "expected IPv4 address"},
+	{"Should match IPv6 address only", NewIPv6Matcher("@pattern@"), "::1", true, ""},
+	{"Should match IPv4-mapped address as IPv6", NewIPv6Matcher("@pattern@"), "::ffff:10.0.0.1", true, ""},
+	{"Should not match IPv4 address as IPv6", NewIPv6Matcher("@pattern@"), "10.0.0.1", false, "expected IPv6 address"},
+}
+
+func TestIPMatcher(t *testing.T) {
+	pattern := "@pattern@"
+
+	for _, tt := range ipMatcherTests {
+		m := tt.matcher
+		assert.True(t, m.CanMatch(pattern), "expected to support pattern")
+
+		t.Logf(tt.desc)
+
+		ok, err := m.Match(pattern, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
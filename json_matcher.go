@@ -47,6 +47,17 @@
 //  	)
 //  );
 //
+// Use New to create JSONMatcher configured with options:
+//
+//  m := gomatch.New(
+//  	gomatch.WithMatchers(
+//  		gomatch.NewStringMatcher("@string@"),
+//  		gomatch.NewNumberMatcher("@number@"),
+//  	),
+//  	gomatch.WithUnorderedArrays(),
+//  	gomatch.WithMaxErrors(10),
+//  )
+//
 package gomatch
 
 import (
@@ -54,7 +65,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
+	"io"
+	"io/ioutil"
 )
 
 var (
@@ -73,6 +85,20 @@ const (
 	patternArray     = "@array@"
 	patternUUID      = "@uuid@"
 	patternEmail     = "@email@"
+	patternEnum      = "@enum@"
+	patternURL       = "@url@"
+	patternHostname  = "@hostname@"
+	patternIP        = "@ip@"
+	patternIPv4      = "@ipv4@"
+	patternIPv6      = "@ipv6@"
+	patternCIDR      = "@cidr@"
+	patternMAC       = "@mac@"
+	patternULID      = "@ulid@"
+	patternKSUID     = "@ksuid@"
+	patternSnowflake = "@snowflake@"
+	patternObjectID  = "@objectid@"
+	patternSemver    = "@semver@"
+	patternDuration  = "@duration@"
 	patternWildcard  = "@wildcard@"
 	patternUnbounded = "@...@"
 )
@@ -102,31 +128,94 @@ type ValueMatcher interface {
 //
 // - EmailMatcher handling "@email@" pattern
 //
+// - EnumMatcher handling "@enum(...)@" pattern
+//
+// - URLMatcher handling "@url@" pattern
+//
+// - HostnameMatcher handling "@hostname@" pattern
+//
+// - IPMatcher handling "@ip@", "@ipv4@" and "@ipv6@" patterns
+//
+// - CIDRMatcher handling "@cidr@" pattern
+//
+// - MACMatcher handling "@mac@" pattern
+//
+// - ULIDMatcher handling "@ulid@" pattern
+//
+// - KSUIDMatcher handling "@ksuid@" pattern
+//
+// - SnowflakeMatcher handling "@snowflake@" pattern
+//
+// - ObjectIDMatcher handling "@objectid@" pattern
+//
+// - SemverMatcher handling "@semver@" pattern
+//
+// - DurationMatcher handling "@duration@" pattern
+//
 // - WildcardMatcher handling "@wildcard@" pattern
 //
-func NewDefaultJSONMatcher() *JSONMatcher {
-	return NewJSONMatcher(
-		NewChainMatcher(
-			[]ValueMatcher{
-				NewStringMatcher(patternString),
-				NewNumberMatcher(patternNumber),
-				NewBoolMatcher(patternBool),
-				NewArrayMatcher(patternArray),
-				NewUUIDMatcher(patternUUID),
-				NewEmailMatcher(patternEmail),
-				NewWildcardMatcher(patternWildcard),
-			},
-		))
+// Given options are applied after the default chain is set.
+func NewDefaultJSONMatcher(opts ...Option) *JSONMatcher {
+	return New(append([]Option{WithMatchers(DefaultMatchers()...)}, opts...)...)
+}
+
+// DefaultMatchers returns value matchers used by NewDefaultJSONMatcher.
+// It may be used to extend the default chain:
+//
+//  m := gomatch.New(gomatch.WithMatchers(append(gomatch.DefaultMatchers(), myMatcher)...))
+func DefaultMatchers() []ValueMatcher {
+	return []ValueMatcher{
+		NewStringMatcher(patternString),
+		NewNumberMatcher(patternNumber),
+		NewBoolMatcher(patternBool),
+		NewArrayMatcher(patternArray),
+		NewUUIDMatcher(patternUUID),
+		NewEmailMatcher(patternEmail),
+		NewEnumMatcher(patternEnum),
+		NewURLMatcher(patternURL),
+		NewHostnameMatcher(patternHostname),
+		NewIPMatcher(patternIP),
+		NewIPv4Matcher(patternIPv4),
+		NewIPv6Matcher(patternIPv6),
+		NewCIDRMatcher(patternCIDR),
+		NewMACMatcher(patternMAC),
+		NewULIDMatcher(patternULID),
+		NewKSUIDMatcher(patternKSUID),
+		NewSnowflakeMatcher(patternSnowflake),
+		NewObjectIDMatcher(patternObjectID),
+		NewSemverMatcher(patternSemver),
+		NewDurationMatcher(patternDuration),
+		NewWildcardMatcher(patternWildcard),
+	}
 }
 
 // NewJSONMatcher creates JSONMatcher with given value matcher.
+// It is equivalent of New(WithMatchers(matcher)).
 func NewJSONMatcher(matcher ValueMatcher) *JSONMatcher {
-	return &JSONMatcher{matcher}
+	return New(WithMatchers(matcher))
 }
 
+// A NumberPrecision defines how JSONMatcher decodes and compares numbers.
+type NumberPrecision int
+
+const (
+	// NumberPrecisionExact decodes numbers as json.Number and compares them exactly,
+	// so big integers and high-precision decimals are not rounded. It is the default.
+	NumberPrecisionExact NumberPrecision = iota
+	// NumberPrecisionFloat64 decodes numbers as float64 like json.Unmarshal does by default.
+	NumberPrecisionFloat64
+)
+
 // A JSONMatcher provides Match method to match two JSONs with pattern matching support.
 type JSONMatcher struct {
-	valueMatcher ValueMatcher
+	config
+}
+
+// SetNumberPrecision changes how numbers are decoded and compared.
+// Use NumberPrecisionFloat64 to get legacy behaviour, where numbers above 2^53 may be rounded.
+// See also WithNumberPrecision.
+func (m *JSONMatcher) SetNumberPrecision(p NumberPrecision) {
+	m.numberPrecision = p
 }
 
 // Match performs deep match of given JSON with an expected JSON pattern.
@@ -175,99 +264,77 @@ type JSONMatcher struct {
 //  }
 //
 // When matching fails then error message contains a path to invalid value.
+//
+// Match parses and resolves the pattern on every call. Use Compile when the same
+// pattern is matched repeatedly.
 func (m *JSONMatcher) Match(expectedJSON, actualJSON string) (bool, error) {
-	var expected, actual interface{}
-	err := json.Unmarshal([]byte(expectedJSON), &expected)
-	if err != nil {
-		return false, errInvalidJSONPattern
-	}
-	err = json.Unmarshal([]byte(actualJSON), &actual)
+	p, err := m.Compile(expectedJSON)
 	if err != nil {
-		return false, errInvalidJSON
-	}
-	path, err := m.deepMatch(expected, actual)
-	if err != nil {
-		if len(path) > 0 {
-			err = fmt.Errorf("%s at path: %s", err.Error(), pathToString(path))
-		}
 		return false, err
 	}
-	return true, nil
+	return p.Match(actualJSON)
 }
 
-func (m *JSONMatcher) deepMatch(expected interface{}, actual interface{}) ([]interface{}, error) {
-	var path []interface{}
-	if reflect.TypeOf(expected) != reflect.TypeOf(actual) && !m.valueMatcher.CanMatch(expected) {
-		return path, errTypesNotEqual
-	}
-
-	switch expected.(type) {
-	case []interface{}:
-		return m.deepMatchArray(expected.([]interface{}), actual.([]interface{}))
-
-	case map[string]interface{}:
-		return m.deepMatchMap(expected.(map[string]interface{}), actual.(map[string]interface{}))
-
-	default:
-		return m.matchValue(expected, actual)
+// MatchBytes works like Match but takes JSONs as byte slices.
+func (m *JSONMatcher) MatchBytes(expectedJSON, actualJSON []byte) (bool, error) {
+	p, err := m.CompileBytes(expectedJSON)
+	if err != nil {
+		return false, err
 	}
+	return p.MatchBytes(actualJSON)
 }
 
-func (m *JSONMatcher) deepMatchArray(expected, actual []interface{}) ([]interface{}, error) {
-	var path []interface{}
-	unbounded := false
-	for i, v := range expected {
-		if isUnbounded(v) {
-			unbounded = true
-			break
-		}
-		if i == len(actual) {
-			 break
-		}
-		keyPath, err := m.deepMatch(v, actual[i])
-		if err != nil {
-			return append(keyPath, i), err
-		}
+// MatchReader works like Match but reads JSONs from given readers.
+func (m *JSONMatcher) MatchReader(expectedJSON, actualJSON io.Reader) (bool, error) {
+	data, err := ioutil.ReadAll(expectedJSON)
+	if err != nil {
+		return false, err
 	}
-	if !unbounded && len(expected) != len(actual) {
-		return path, errArraysLenNotEqual
+	p, err := m.CompileBytes(data)
+	if err != nil {
+		return false, err
 	}
-	return path, nil
+	return p.MatchReader(actualJSON)
 }
 
-func (m *JSONMatcher) deepMatchMap(expected, actual map[string]interface{}) ([]interface{}, error) {
-	var path []interface{}
-	unbounded := false
-	for k, v1 := range expected {
-		if isUnbounded(k) {
-			unbounded = true
-			continue
-		}
-		v2, ok := actual[k]
-		if !ok {
-			return path, fmt.Errorf(`expected key "%s"`, k)
-		}
-		keyPath, err := m.deepMatch(v1, v2)
-		if err != nil {
-			return append(keyPath, k), err
-		}
+// MatchValue works like Match but takes values instead of JSONs.
+// Both values are marshalled with encoding/json so they may be any Go values,
+// e.g. structs, values decoded from JSON or json.RawMessage.
+//
+//  ok, err := m.MatchValue(
+//  	map[string]interface{}{"id": "@number@"},
+//  	User{ID: 351},
+//  )
+func (m *JSONMatcher) MatchValue(expected, actual interface{}) (bool, error) {
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", errInvalidJSONPattern.Error(), err.Error())
 	}
-	if !unbounded && len(expected) != len(actual) {
-		return path, errUnexpectedKey
+	p, err := m.CompileBytes(expectedJSON)
+	if err != nil {
+		return false, err
 	}
-	return path, nil
+	return p.MatchValue(actual)
 }
 
-func (m *JSONMatcher) matchValue(expected, actual interface{}) ([]interface{}, error) {
-	var path []interface{}
-	if m.valueMatcher.CanMatch(expected) {
-		_, err := m.valueMatcher.Match(expected, actual)
-		return path, err
+// decodeJSON decodes data into interface{} using json.Number for numbers if useNumber is set.
+func decodeJSON(data []byte, useNumber bool) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if useNumber {
+		dec.UseNumber()
+	}
+	err := dec.Decode(&v)
+	if err == nil {
+		if _, err = dec.Token(); err == io.EOF {
+			return v, nil
+		}
 	}
-	if expected != actual {
-		return path, errValuesNotEqual
+	// use json.Unmarshal to get an error consistent with standard decoding
+	if err = json.Unmarshal(data, new(interface{})); err != nil {
+		return nil, err
 	}
-	return path, nil
+	return nil, errInvalidJSON
 }
 
 func pathToString(path []interface{}) string {
@@ -289,8 +356,9 @@ func pathToString(path []interface{}) string {
 	return b.String()
 }
 
+// isUnbounded checks if p is the unbounded pattern of a compiled pattern.
 func isUnbounded(p interface{}) bool {
-	return isPattern(p, patternUnbounded)
+	return isPattern(p, unboundedMarker)
 }
 
 func isPattern(p interface{}, pattern string) bool {
@@ -1,6 +1,8 @@
 package gomatch
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,6 +64,27 @@ var jsonMatcherTests = []struct {
 		false,
 		"values are not equal",
 	},
+	{
+		"Should succeed if numbers are equal but have different notation",
+		`[1.50, 100, 0.001]`,
+		`[1.5, 1e2, 1E-3]`,
+		true,
+		"",
+	},
+	{
+		"Should fail if big integers are not equal",
+		`{"id": 9007199254740993}`,
+		`{"id": 9007199254740992}`,
+		false,
+		"values are not equal at path: id",
+	},
+	{
+		"Should fail if high-precision decimals are not equal",
+		`0.30000000000000000001`,
+		`0.3`,
+		false,
+		"values are not equal",
+	},
 	{
 		"Should succeed if objects are equal",
 		`
@@ -423,3 +446,109 @@ func TestJSONMatcherWithDefaultMatchers(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, ok)
 }
+
+func TestJSONMatcherWithFloat64NumberPrecision(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	m.SetNumberPrecision(NumberPrecisionFloat64)
+
+	ok, err := m.Match(`{"id": 9007199254740993, "n": "@number@"}`, `{"id": 9007199254740992, "n": 1}`)
+
+	assert.Nil(t, err)
+	assert.True(t, ok, "expected numbers to be rounded to float64")
+}
+
+func TestJSONMatcherMatchBytes(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+
+	ok, err := m.MatchBytes([]byte(`{"id": "@number@"}`), []byte(`{"id": 1}`))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = m.MatchBytes([]byte(`{"id": "@number@"}`), []byte(`{"id": "1"}`))
+	assert.EqualError(t, err, "expected number at path: id")
+	assert.False(t, ok)
+}
+
+func TestJSONMatcherMatchReader(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+
+	ok, err := m.MatchReader(strings.NewReader(`{"id": "@number@"}`), strings.NewReader(`{"id": 1}`))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = m.MatchReader(strings.NewReader(`{"id": "@number@"}`), strings.NewReader(`{"id":`))
+	assert.EqualError(t, err, "invalid JSON")
+	assert.False(t, ok)
+}
+
+var matchValueTests = []struct {
+	desc   string
+	p      interface{}
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{
+		"Should match decoded values",
+		map[string]interface{}{"id": "@number@", "tags": []interface{}{"a", "@...@"}},
+		map[string]interface{}{"id": 1., "tags": []interface{}{"a", "b"}},
+		true,
+		"",
+	},
+	{
+		"Should match struct",
+		map[string]interface{}{"id": "@number@", "name": "John Smith", "email": "@email@"},
+		struct {
+			ID    int64  `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}{9007199254740993, "John Smith", "john.smith@example.com"},
+		true,
+		"",
+	},
+	{
+		"Should match json.RawMessage",
+		json.RawMessage(`{"id": "@number@", "@...@": ""}`),
+		json.RawMessage(`{"id": 1, "name": "John"}`),
+		true,
+		"",
+	},
+	{
+		"Should compare big integers exactly",
+		map[string]interface{}{"id": int64(9007199254740993)},
+		map[string]interface{}{"id": int64(9007199254740992)},
+		false,
+		"values are not equal at path: id",
+	},
+	{
+		"Should fail if actual value can't be marshalled",
+		"@wildcard@",
+		make(chan int),
+		false,
+		"invalid JSON: json: unsupported type: chan int",
+	},
+	{
+		"Should fail if expected value can't be marshalled",
+		make(chan int),
+		1,
+		false,
+		"invalid JSON pattern: json: unsupported type: chan int",
+	},
+}
+
+func TestJSONMatcherMatchValue(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range matchValueTests {
+		t.Logf(tt.desc)
+
+		ok, err := m.MatchValue(tt.p, tt.v)
+
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package gomatch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+const patternJWT = "jwt"
+
+var (
+	errNotJWT           = errors.New("expected JWT")
+	errInvalidSignature = errors.New("invalid JWT signature")
+)
+
+// jwtHMACs holds hash functions of JWT algorithms using HMAC.
+var jwtHMACs = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// A jwtPattern is a "@jwt@" pattern of a compiled pattern. It matches JWTs in JWS
+// compact serialization and optionally matches the decoded header and claims
+// with nested patterns:
+//
+//  "@jwt@"
+//  "@jwt@.header({\"alg\": \"HS256\", \"@...@\": \"\"}).claims({\"sub\": \"@string@\", \"@...@\": \"\"})"
+//  "@jwt@.hmac(\"secret\")"
+//
+// Signatures are not verified unless hmac(key) is used, which verifies HS256, HS384
+// and HS512 signatures. Mismatches of nested patterns are reported at paths
+// continuing with "header" or "claims", e.g. "token.claims.sub".
+type jwtPattern struct {
+	header interface{}
+	claims interface{}
+	// hasHeader and hasClaims are true when header and claims have nested patterns
+	hasHeader bool
+	hasClaims bool
+	key       []byte
+}
+
+func (c *config) parseJWTPattern(s string, parsed *parsedPattern) (nestedPattern, error) {
+	if parsed.hasArgs {
+		return nil, fmt.Errorf(`invalid JWT pattern "%s": unexpected arguments`, s)
+	}
+	p := &jwtPattern{}
+	for _, e := range parsed.expanders {
+		args, err := parseArgs(e.args)
+		if err != nil || len(args) != 1 {
+			return nil, fmt.Errorf(`invalid JWT pattern "%s": expected a single argument of %s()`, s, e.name)
+		}
+		switch e.name {
+		case "header":
+			p.header, err = c.decodeNestedPattern(s, args[0])
+			p.hasHeader = true
+		case "claims":
+			p.claims, err = c.decodeNestedPattern(s, args[0])
+			p.hasClaims = true
+		case "hmac":
+			if !args[0].isString() {
+				return nil, fmt.Errorf(`invalid JWT pattern "%s": expected a string key of hmac()`, s)
+			}
+			p.key = []byte(args[0].String())
+		default:
+			return nil, fmt.Errorf(`invalid JWT pattern "%s": unknown expander %s()`, s, e.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (j *jwtPattern) compile(p *CompiledPattern, path []interface{}) error {
+	var err error
+	if j.hasHeader {
+		if j.header, err = p.compile(j.header, append(path, "header")); err != nil {
+			return err
+		}
+	}
+	if j.hasClaims {
+		if j.claims, err = p.compile(j.claims, append(path, "claims")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jwtPattern) match(s *matchState, v interface{}) {
+	str, ok := v.(string)
+	if !ok {
+		s.fail(errNotJWT)
+		return
+	}
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		s.fail(errNotJWT)
+		return
+	}
+	header, ok := j.decodePart(s, parts[0])
+	if !ok {
+		s.fail(errNotJWT)
+		return
+	}
+	claims, ok := j.decodePart(s, parts[1])
+	if !ok {
+		s.fail(errNotJWT)
+		return
+	}
+	signature, err := base64.RawURLEncoding.Strict().DecodeString(parts[2])
+	if err != nil {
+		s.fail(errNotJWT)
+		return
+	}
+	if j.key != nil {
+		if err := j.verify(header, parts[0]+"."+parts[1], signature); err != nil {
+			s.fail(err)
+			return
+		}
+	}
+	if j.hasHeader {
+		s.push("header")
+		s.deepMatch(j.header, header)
+		s.pop()
+	}
+	if j.hasClaims && !s.done() {
+		s.push("claims")
+		s.deepMatch(j.claims, claims)
+		s.pop()
+	}
+}
+
+// decodePart decodes a JSON object of base64url encoded header or claims.
+func (j *jwtPattern) decodePart(s *matchState, part string) (interface{}, bool) {
+	data, err := base64.RawURLEncoding.Strict().DecodeString(part)
+	if err != nil || strings.ContainsAny(part, "\r\n") {
+		return nil, false
+	}
+	v, err := decodeJSON(data, s.p.useNumber())
+	if err != nil {
+		return nil, false
+	}
+	_, ok := v.(map[string]interface{})
+	return v, ok
+}
+
+// verify verifies HMAC signature of signed content using algorithm from the header.
+func (j *jwtPattern) verify(header interface{}, signed string, signature []byte) error {
+	alg, _ := header.(map[string]interface{})["alg"].(string)
+	h, ok := jwtHMACs[alg]
+	if !ok {
+		return fmt.Errorf(`expected JWT signed with HMAC, got alg "%s"`, alg)
+	}
+	mac := hmac.New(h, j.key)
+	mac.Write([]byte(signed))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return errInvalidSignature
+	}
+	return nil
+}
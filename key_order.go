@@ -0,0 +1,164 @@
+package gomatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// keyOrders holds keys of decoded objects in document order by paths of objects, see pathKey.
+type keyOrders map[string][]string
+
+// pathKey returns a key of a path in a JSON document. Unlike pathToString
+// it is unambiguous, e.g. for keys containing dots.
+func pathKey(path []interface{}) string {
+	var b strings.Builder
+	for _, v := range path {
+		switch v := v.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(v) + "]")
+		case string:
+			b.WriteString(strconv.Quote(v))
+		}
+	}
+	return b.String()
+}
+
+// A decodedKeys holds keys of objects decoded by decodeOrderedJSON.
+type decodedKeys struct {
+	orders keyOrders
+	// duplicates hold paths of duplicate keys, see WithDuplicateKeyDetection
+	duplicates [][]interface{}
+}
+
+// decodeOrderedJSON works like decodeJSON but also returns keys of all objects
+// in document order and paths of duplicate keys.
+func decodeOrderedJSON(data []byte, useNumber bool) (interface{}, *decodedKeys, error) {
+	d := &orderedDecoder{
+		dec:  json.NewDecoder(bytes.NewReader(data)),
+		keys: &decodedKeys{orders: make(keyOrders)},
+	}
+	if useNumber {
+		d.dec.UseNumber()
+	}
+	v, err := d.value()
+	if err == nil {
+		if _, err = d.dec.Token(); err == io.EOF {
+			return v, d.keys, nil
+		}
+	}
+	// use decodeJSON to get an error consistent with standard decoding
+	if _, err = decodeJSON(data, useNumber); err == nil {
+		err = errInvalidJSON
+	}
+	return nil, nil, err
+}
+
+type orderedDecoder struct {
+	dec  *json.Decoder
+	keys *decodedKeys
+	path []interface{}
+}
+
+func (d *orderedDecoder) value() (interface{}, error) {
+	t, err := d.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('['):
+		elements := []interface{}{}
+		for i := 0; d.dec.More(); i++ {
+			d.path = append(d.path, i)
+			e, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			d.path = d.path[:len(d.path)-1]
+			elements = append(elements, e)
+		}
+		_, err := d.dec.Token()
+		return elements, err
+	case json.Delim('{'):
+		m := make(map[string]interface{})
+		var keys []string
+		for d.dec.More() {
+			t, err := d.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k := t.(string)
+			d.path = append(d.path, k)
+			v, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			// like encoding/json the last value of a duplicate key is kept
+			if _, ok := m[k]; ok {
+				d.keys.duplicates = append(d.keys.duplicates, append([]interface{}(nil), d.path...))
+			} else {
+				keys = append(keys, k)
+			}
+			d.path = d.path[:len(d.path)-1]
+			m[k] = v
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return nil, err
+		}
+		d.keys.orders[pathKey(d.path)] = keys
+		return m, nil
+	}
+	return t, nil
+}
+
+// keyOrderChecker checks if keys of an actual object are in the order of keys of the pattern.
+// Keys missing in either of objects are ignored.
+type keyOrderChecker struct {
+	index   map[string]int
+	last    int
+	lastKey string
+}
+
+func newKeyOrderChecker(expectedKeys []string) *keyOrderChecker {
+	index := make(map[string]int, len(expectedKeys))
+	for i, k := range expectedKeys {
+		index[k] = i
+	}
+	return &keyOrderChecker{index: index, last: -1}
+}
+
+// next checks the next key of actual object.
+func (c *keyOrderChecker) next(k string) error {
+	i, ok := c.index[k]
+	if !ok || isUnbounded(k) {
+		return nil
+	}
+	if i < c.last {
+		return fmt.Errorf(`expected key "%s" before "%s"`, k, c.lastKey)
+	}
+	c.last, c.lastKey = i, k
+	return nil
+}
+
+// checkKeyOrder reports keys of actual object at current path which are out of order
+// of expected keys. Objects of unknown key order, e.g. decoded from an embedded JSON,
+// are not checked.
+func (s *matchState) checkKeyOrder(expected *compiledObject) {
+	if expected.keys == nil {
+		return
+	}
+	actualKeys, ok := s.keyOrders[pathKey(s.path)]
+	if !ok {
+		return
+	}
+	c := newKeyOrderChecker(expected.keys)
+	for _, k := range actualKeys {
+		if err := c.next(k); err != nil {
+			s.fail(err)
+			return
+		}
+	}
+}
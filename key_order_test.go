@@ -0,0 +1,90 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var strictKeyOrderTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should match keys in order", `{"id": 1, "name": "joe", "age": 5}`, `{"id": 1, "name": "joe", "age": 5}`, true, ""},
+	{"Should not match keys out of order", `{"id": 1, "name": "joe", "age": 5}`, `{"id": 1, "age": 5, "name": "joe"}`, false, `expected key "name" before "age"`},
+	{"Should report path of object", `{"user": {"a": 1, "b": 2}}`, `{"user": {"b": 2, "a": 1}}`, false, `expected key "a" before "b" at path: user`},
+	{"Should check objects in arrays", `[{"a": 1, "b": 2}, {"a": 1, "b": 2}]`, `[{"a": 1, "b": 2}, {"b": 2, "a": 1}]`, false, `expected key "a" before "b" at path: [1]`},
+	{"Should ignore extra keys", `{"a": 1, "b": 2, "@...@": ""}`, `{"x": 0, "a": 1, "y": 0, "b": 2}`, true, ""},
+	{"Should ignore position of unbounded pattern", `{"@...@": "", "a": 1, "b": 2}`, `{"a": 1, "b": 2, "x": 0}`, true, ""},
+	{"Should check keys matched by value patterns", `{"a": "@number@", "b": "@string@"}`, `{"b": "x", "a": 1}`, false, `expected key "a" before "b"`},
+	{"Should not check order of embedded JSON", `{"p": "@json@({\"a\": 1, \"b\": 2})"}`, `{"p": "{\"b\": 2, \"a\": 1}"}`, true, ""},
+}
+
+func TestStrictKeyOrder(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithStrictKeyOrder())
+	for _, tt := range strictKeyOrderTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+
+		ok, err = m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestStrictKeyOrderDisabled(t *testing.T) {
+	ok, err := NewDefaultJSONMatcher().Match(`{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`)
+
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestStrictKeyOrderUnorderedArrays(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithStrictKeyOrder(), WithUnorderedArrays())
+
+	ok, err := m.Match(`[{"a": 1, "b": 1}, {"a": 2, "b": 2}]`, `[{"a": 2, "b": 2}, {"b": 1, "a": 1}]`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, "no matching array element at path: [0]")
+}
+
+func TestStrictKeyOrderKeysWithDots(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithStrictKeyOrder())
+
+	ok, err := m.Match(`{"a": {"b": {"x": 1, "y": 2}}, "a.b": {"y": 2, "x": 1}}`, `{"a": {"b": {"x": 1, "y": 2}}, "a.b": {"x": 1, "y": 2}}`)
+
+	assert.False(t, ok)
+	assert.EqualError(t, err, `expected key "y" before "x" at path: a.b`)
+}
+
+func TestDecodeOrderedJSON(t *testing.T) {
+	v, keys, err := decodeOrderedJSON([]byte(`{"b": [{"y": 1, "x": 2}], "a": null, "b": []}`), true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": nil, "b": []interface{}{}}, v)
+	assert.Equal(t, []string{"b", "a"}, keys.orders[pathKey(nil)])
+	assert.Equal(t, []string{"y", "x"}, keys.orders[pathKey([]interface{}{"b", 0})])
+	assert.Equal(t, [][]interface{}{{"b"}}, keys.duplicates)
+
+	_, _, err = decodeOrderedJSON([]byte(`{"a": 1} 2`), true)
+	_, expectedErr := decodeJSON([]byte(`{"a": 1} 2`), true)
+	assert.Equal(t, expectedErr, err)
+}
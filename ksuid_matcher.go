@@ -0,0 +1,57 @@
+package gomatch
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var errNotKSUID = errors.New("expected KSUID")
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ksuidEpoch is KSUID epoch in Unix seconds, 2014-05-13T16:53:20Z
+	ksuidEpoch = 1400000000
+)
+
+// A KSUIDMatcher matches KSUIDs, e.g. "0ujtsYcgvSTl8PAuAdqWYSMnLOv".
+//
+// Supported expanders check the embedded timestamp:
+//
+//  @ksuid@.after("2020-01-01T00:00:00Z")
+//  @ksuid@.before("2030-01-01T00:00:00Z")
+//  @ksuid@.within("24h")
+type KSUIDMatcher struct {
+	*timestampIDMatcher
+}
+
+// NewKSUIDMatcher creates KSUIDMatcher.
+func NewKSUIDMatcher(pattern string) *KSUIDMatcher {
+	return &KSUIDMatcher{newTimestampIDMatcher(pattern, ksuidTimestamp, func(m *timestampIDMatcher) ValueMatcher {
+		return &KSUIDMatcher{m}
+	})}
+}
+
+// ksuidTimestamp decodes 20 bytes of base62 encoded KSUID and returns
+// a timestamp stored in its first 4 bytes.
+func ksuidTimestamp(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 27 {
+		return time.Time{}, errNotKSUID
+	}
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base62Alphabet, s[i])
+		if d < 0 {
+			return time.Time{}, errNotKSUID
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(d)))
+	}
+	if n.BitLen() > 160 {
+		return time.Time{}, errNotKSUID
+	}
+	seconds := new(big.Int).Rsh(n, 128).Int64()
+	return time.Unix(seconds+ksuidEpoch, 0), nil
+}
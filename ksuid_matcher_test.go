@@ -0,0 +1,45 @@
+package gomatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ksuidMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match KSUID", "@ksuid@", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true, ""},
+	{"Should match max KSUID", "@ksuid@", "aWgEPTl1tmebfsQzFP4bxwgy80V", true, ""},
+	{"Should not match overflowing KSUID", "@ksuid@", "aWgEPTl1tmebfsQzFP4bxwgy80W", false, "expected KSUID"},
+	{"Should not match invalid character", "@ksuid@", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", false, "expected KSUID"},
+	{"Should not match too long KSUID", "@ksuid@", "0ujtsYcgvSTl8PAuAdqWYSMnLOvv", false, "expected KSUID"},
+	{"Should not match number", "@ksuid@", 1., false, "expected KSUID"},
+	{"Should match timestamp in range", `@ksuid@.after("2017-10-10T04:00:46Z").before("2017-10-10T04:00:48Z")`, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true, ""},
+	{"Should not match timestamp out of range", `@ksuid@.after("2018-01-01T00:00:00Z")`, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", false, "expected timestamp after 2018-01-01T00:00:00Z, got 2017-10-10T04:00:47Z"},
+	{"Should match timestamp close to now", `@ksuid@.within("24h")`, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true, ""},
+}
+
+func TestKSUIDMatcher(t *testing.T) {
+	m := NewKSUIDMatcher("@ksuid@")
+	m.SetClock(func() time.Time { return time.Date(2017, 10, 10, 0, 0, 0, 0, time.UTC) })
+	for _, tt := range ksuidMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// patternLikeRe matches strings which look like a value pattern, e.g. "@number@".
+var patternLikeRe = regexp.MustCompile(`^@[^@\s]+@`)
+
+// A LintIssue describes a suspicious construct found in a JSON pattern.
+type LintIssue struct {
+	Msg    string
+	Path   string
+	Line   int
+	Column int
+}
+
+func (i LintIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", i.Line, i.Column, i.Msg)
+	}
+	return fmt.Sprintf("line %d, column %d: %s at path: %s", i.Line, i.Column, i.Msg, i.Path)
+}
+
+// A LintError is returned by Validate when a pattern has lint issues.
+type LintError struct {
+	Issues []LintIssue
+}
+
+func (e *LintError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return "pattern has lint issues: " + strings.Join(msgs, "; ")
+}
+
+// Lint checks expected JSON pattern for constructs which are most likely mistakes:
+//
+// - unknown value patterns, e.g. "@nubmer@", which would be compared as literal strings
+//
+// - value patterns with unknown expanders or invalid arguments of expanders,
+// e.g. "@number@.between(10)", which would be compared as literal strings as well
+//
+// - unbounded pattern "@...@" placed where it has no effect
+//
+// - duplicate object keys
+//
+// - overly permissive objects, e.g. consisting solely of "@wildcard@" fields
+//
+// Patterns nested in other patterns, e.g. in "@json@(...)", are checked as well,
+// their issues are reported at the position of the outer pattern.
+//
+// Returned error is a *PatternError when the pattern is not a valid JSON.
+func (m *JSONMatcher) Lint(expectedJSON string) ([]LintIssue, error) {
+	// duplicate keys are reported as issues, also when the matcher rejects them
+	c := m.config
+	c.detectDuplicateKeys = false
+	if _, err := (&JSONMatcher{c}).Compile(expectedJSON); err != nil {
+		return nil, err
+	}
+	l := &linter{
+		config: &m.config,
+		data:   []byte(expectedJSON),
+		dec:    json.NewDecoder(strings.NewReader(expectedJSON)),
+	}
+	l.dec.UseNumber()
+	if _, err := l.lintValue(); err != nil {
+		return nil, newPatternError(l.data, err)
+	}
+	return l.issues, nil
+}
+
+// Validate returns an error if expected JSON pattern is invalid or has any lint issues.
+// See Lint for performed checks.
+func (m *JSONMatcher) Validate(expectedJSON string) error {
+	issues, err := m.Lint(expectedJSON)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return &LintError{issues}
+	}
+	return nil
+}
+
+type linter struct {
+	*config
+	data   []byte
+	dec    *json.Decoder
+	path   []interface{}
+	issues []LintIssue
+	// root is the length of path of the root value, it is not 0 for nested patterns
+	root int
+}
+
+// A lintKind tells what kind of value was checked by the linter.
+type lintKind int
+
+const (
+	lintOther lintKind = iota
+	lintWildcard
+	lintUnbounded
+)
+
+// lintValue reads and checks next value from the decoder.
+func (l *linter) lintValue() (lintKind, error) {
+	offset := l.tokenStart()
+	t, err := l.dec.Token()
+	if err != nil {
+		return lintOther, err
+	}
+	switch t {
+	case json.Delim('{'):
+		return lintOther, l.lintObject(offset)
+	case json.Delim('['):
+		return lintOther, l.lintArray()
+	}
+	s, ok := t.(string)
+	if !ok {
+		return lintOther, nil
+	}
+	p, ok := l.compileString(s).(string)
+	if !ok {
+		return lintOther, nil
+	}
+	if p == patternUnbounded {
+		if len(l.path) == l.root {
+			l.report(offset, fmt.Sprintf(`unbounded pattern "%s" has no effect outside of an array or object`, s))
+		} else if _, ok := l.path[len(l.path)-1].(string); ok {
+			l.report(offset, fmt.Sprintf(`unbounded pattern "%s" has no effect as an object value, use it as a key`, s))
+		}
+		return lintUnbounded, nil
+	}
+	if parseNullPattern(p) != nil {
+		return lintOther, nil
+	}
+	if e, _ := parseExprPattern(p); e != nil {
+		return lintOther, nil
+	}
+	if n, _ := l.parseNestedPattern(p); n != nil {
+		l.lintNested(offset, p)
+		return lintOther, nil
+	}
+	if r, _ := l.parseReferencePattern(p); r != nil {
+		return lintOther, nil
+	}
+	if l.resolvePattern(p) != nil {
+		if isWildcard(l.valueMatcher, p) {
+			return lintWildcard, nil
+		}
+		return lintOther, nil
+	}
+	if err := l.expanderError(p); err != nil {
+		l.report(offset, fmt.Sprintf(`invalid pattern "%s" will be compared as a string: %s`, s, err.Error()))
+	} else if l.patternLike(p) {
+		l.report(offset, fmt.Sprintf(`unknown pattern "%s" will be compared as a string`, s))
+	}
+	return lintOther, nil
+}
+
+// expanderError returns an error of the first expander of pattern p which cannot be built,
+// e.g. because of its invalid arguments, when p has a base pattern of a matcher using
+// expanders. It returns nil otherwise.
+func (l *linter) expanderError(p string) error {
+	parsed, ok := parsePattern(p)
+	if !ok || len(parsed.expanders) == 0 {
+		return nil
+	}
+	vp := l.resolvePattern(parsed.head(p, 0))
+	if vp == nil {
+		return nil
+	}
+	u, ok := vp.matcher.(expanderUser)
+	if !ok {
+		return nil
+	}
+	_, err := u.patternExpanders().buildChecks(parsed.expanders)
+	return err
+}
+
+// lintNested checks JSON patterns nested in pattern p, e.g. in "@json@(...)".
+// Their issues are reported at offset of p with paths continuing into nested patterns.
+func (l *linter) lintNested(offset int64, p string) {
+	parsed, _ := parsePattern(p)
+	line, column := position(l.data, offset+1)
+	for _, n := range nestedPatterns(parsed) {
+		nested := &linter{
+			config: l.config,
+			data:   []byte(n.arg),
+			dec:    json.NewDecoder(strings.NewReader(string(n.arg))),
+			path:   append(append([]interface{}{}, l.path...), n.path...),
+		}
+		nested.root = len(nested.path)
+		nested.dec.UseNumber()
+		if _, err := nested.lintValue(); err != nil {
+			// invalid nested patterns are rejected by Compile
+			continue
+		}
+		for _, issue := range nested.issues {
+			issue.Line, issue.Column = line, column
+			l.issues = append(l.issues, issue)
+		}
+	}
+}
+
+// A nestedArg is a JSON pattern given as an argument of a pattern with nested patterns.
+type nestedArg struct {
+	arg patternArg
+	// path is a path of the nested pattern relative to the outer one
+	path []interface{}
+}
+
+// nestedPatterns returns JSON patterns nested in a parsed pattern with nested patterns.
+func nestedPatterns(parsed *parsedPattern) []nestedArg {
+	var nested []nestedArg
+	add := func(raw string, path ...interface{}) {
+		if args, err := parseArgs(raw); err == nil && len(args) == 1 {
+			nested = append(nested, nestedArg{args[0], path})
+		}
+	}
+	switch parsed.name {
+	case patternJSON:
+		if parsed.hasArgs {
+			add(parsed.args)
+		}
+		return nested
+	case patternBase64, patternJWT:
+		for _, e := range parsed.expanders {
+			switch e.name {
+			case "json":
+				add(e.args)
+			case "header", "claims":
+				add(e.args, e.name)
+			}
+		}
+		return nested
+	}
+	if n := len(parsed.expanders); n > 0 && parsed.expanders[n-1].name == expanderEvery {
+		add(parsed.expanders[n-1].args)
+	}
+	return nested
+}
+
+func (l *linter) lintObject(offset int64) error {
+	keys := make(map[string]bool)
+	permissive := true
+	for l.dec.More() {
+		keyOffset := l.tokenStart()
+		t, err := l.dec.Token()
+		if err != nil {
+			return err
+		}
+		k := t.(string)
+		l.path = append(l.path, k)
+		if keys[k] {
+			l.report(keyOffset, fmt.Sprintf(`duplicate key "%s"`, k))
+		}
+		keys[k] = true
+		if isUnbounded(l.compileKey(k)) {
+			if err := l.skipValue(); err != nil {
+				return err
+			}
+		} else {
+			// keys which are patterns are converted to default delimiters, other keys are kept
+			if p, ok := l.compileString(k).(string); ok && (p != k || l.patternLike(k)) {
+				l.report(keyOffset, fmt.Sprintf(`pattern "%s" has no effect as a key`, k))
+			}
+			kind, err := l.lintValue()
+			if err != nil {
+				return err
+			}
+			permissive = permissive && kind == lintWildcard
+		}
+		l.path = l.path[:len(l.path)-1]
+	}
+	if _, err := l.dec.Token(); err != nil {
+		return err
+	}
+	if permissive && len(keys) > 0 {
+		l.report(offset, "object matches almost any object, it has only wildcard fields")
+	}
+	return nil
+}
+
+func (l *linter) lintArray() error {
+	unboundedAt, unboundedOffset := -1, int64(0)
+	for i := 0; l.dec.More(); i++ {
+		offset := l.tokenStart()
+		if unboundedAt > -1 {
+			l.path = append(l.path, unboundedAt)
+			open, close := l.delimiters()
+			l.report(unboundedOffset, fmt.Sprintf(`unbounded pattern "%s...%s" has no effect unless it is the last array element`, open, close))
+			l.path = l.path[:len(l.path)-1]
+			unboundedAt = -1
+		}
+		l.path = append(l.path, i)
+		kind, err := l.lintValue()
+		if err != nil {
+			return err
+		}
+		if kind == lintUnbounded {
+			unboundedAt, unboundedOffset = i, offset
+		}
+		l.path = l.path[:len(l.path)-1]
+	}
+	_, err := l.dec.Token()
+	return err
+}
+
+func (l *linter) skipValue() error {
+	var v json.RawMessage
+	return l.dec.Decode(&v)
+}
+
+// tokenStart returns offset of the next token skipping whitespaces and separators.
+func (l *linter) tokenStart() int64 {
+	offset := l.dec.InputOffset()
+	for offset < int64(len(l.data)) && isSeparator(l.data[offset]) {
+		offset++
+	}
+	return offset
+}
+
+func isSeparator(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', ',', ':':
+		return true
+	}
+	return false
+}
+
+func (l *linter) report(offset int64, msg string) {
+	line, column := position(l.data, offset+1)
+	l.issues = append(l.issues, LintIssue{msg, pathToString(reversePath(l.path)), line, column})
+}
+
+func reversePath(path []interface{}) []interface{} {
+	reversed := make([]interface{}, len(path))
+	for i, v := range path {
+		reversed[len(path)-1-i] = v
+	}
+	return reversed
+}
+
+// isWildcard checks if pattern p is handled by a WildcardMatcher.
+func isWildcard(m ValueMatcher, p interface{}) bool {
+	switch m.(type) {
+	case *WildcardMatcher:
+		return m.CanMatch(p)
+	case *ChainMatcher:
+		for _, m := range m.(*ChainMatcher).matchers {
+			if m.CanMatch(p) {
+				return isWildcard(m, p)
+			}
+		}
+	}
+	return false
+}
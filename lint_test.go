@@ -0,0 +1,144 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var lintTests = []struct {
+	desc   string
+	p      string
+	issues []string
+}{
+	{
+		"Should not report issues for a valid pattern",
+		`{"id": "@number@", "tags": ["a", "@...@"], "@...@": ""}`,
+		nil,
+	},
+	{
+		"Should report unknown pattern",
+		`{"id": "@nubmer@"}`,
+		[]string{`line 1, column 8: unknown pattern "@nubmer@" will be compared as a string at path: id`},
+	},
+	{
+		"Should not report strings which do not look like a pattern",
+		`{"email": "john@example.com", "at": "@", "handle": "@john"}`,
+		nil,
+	},
+	{
+		"Should report unbounded pattern used as an object value",
+		`{"id": 1, "rest": "@...@"}`,
+		[]string{`line 1, column 19: unbounded pattern "@...@" has no effect as an object value, use it as a key at path: rest`},
+	},
+	{
+		"Should report unbounded pattern used as a root value",
+		`"@...@"`,
+		[]string{`line 1, column 1: unbounded pattern "@...@" has no effect outside of an array or object`},
+	},
+	{
+		"Should report unbounded pattern which is not the last array element",
+		"[\n\t1,\n\t\"@...@\",\n\t3\n]",
+		[]string{`line 3, column 2: unbounded pattern "@...@" has no effect unless it is the last array element at path: [1]`},
+	},
+	{
+		"Should report pattern used as a key",
+		`{"@string@": 1}`,
+		[]string{`line 1, column 2: pattern "@string@" has no effect as a key at path: @string@`},
+	},
+	{
+		"Should report duplicate keys",
+		`{"user": {"id": 1, "name": "John", "id": 2}}`,
+		[]string{`line 1, column 36: duplicate key "id" at path: user.id`},
+	},
+	{
+		"Should report object consisting solely of wildcard fields",
+		`{"items": [{"id": "@wildcard@", "name": "@wildcard@", "@...@": ""}]}`,
+		[]string{`line 1, column 12: object matches almost any object, it has only wildcard fields at path: items[0]`},
+	},
+	{
+		"Should report invalid arguments of an expander",
+		`{"id": "@number@.between(1, 1e999999999999)"}`,
+		[]string{`line 1, column 8: invalid pattern "@number@.between(1, 1e999999999999)" will be compared as a string: ` +
+			`invalid arguments of between(): number out of range at path: id`},
+	},
+	{
+		"Should report invalid semver constraint",
+		`{"version": "@semver@.satisfies(\">= 1.2\")"}`,
+		[]string{`line 1, column 13: invalid pattern "@semver@.satisfies(">= 1.2")" will be compared as a string: ` +
+			`invalid arguments of satisfies(): invalid version constraint ">=" at path: version`},
+	},
+	{
+		"Should report unknown expander",
+		`{"id": "@number@.positiv()"}`,
+		[]string{`line 1, column 8: invalid pattern "@number@.positiv()" will be compared as a string: unknown expander positiv() at path: id`},
+	},
+	{
+		"Should report issues of nested patterns",
+		`{"payload": "@json@({\"id\": \"@nubmer@\"})", "items": "@array@.every({\"id\": 1, \"id\": 2})"}`,
+		[]string{
+			`line 1, column 13: unknown pattern "@nubmer@" will be compared as a string at path: payload.id`,
+			`line 1, column 56: duplicate key "id" at path: items.id`,
+		},
+	},
+	{
+		"Should report issues of JWT and base64 nested patterns",
+		`["@jwt@.claims({\"sub\": \"@strnig@\"})", "@base64@.json(\"@...@\")"]`,
+		[]string{
+			`line 1, column 2: unknown pattern "@strnig@" will be compared as a string at path: [0].claims.sub`,
+			`line 1, column 43: unbounded pattern "@...@" has no effect outside of an array or object at path: [1]`,
+		},
+	},
+	{
+		"Should report multiple issues",
+		`{"id": "@uuid4@", "name": "@strnig@"}`,
+		[]string{
+			`line 1, column 8: unknown pattern "@uuid4@" will be compared as a string at path: id`,
+			`line 1, column 27: unknown pattern "@strnig@" will be compared as a string at path: name`,
+		},
+	},
+}
+
+func TestLint(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range lintTests {
+		t.Logf(tt.desc)
+
+		issues, err := m.Lint(tt.p)
+
+		assert.Nil(t, err)
+		var actual []string
+		for _, issue := range issues {
+			actual = append(actual, issue.String())
+		}
+		assert.Equal(t, tt.issues, actual)
+	}
+}
+
+func TestLintWithDuplicateKeyDetection(t *testing.T) {
+	m := New(WithMatchers(DefaultMatchers()...), WithDuplicateKeyDetection())
+
+	issues, err := m.Lint(`{"id": 1, "id": 2}`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []LintIssue{{`duplicate key "id"`, "id", 1, 11}}, issues)
+}
+
+func TestLintInvalidPattern(t *testing.T) {
+	issues, err := NewDefaultJSONMatcher().Lint(`{"id":}`)
+
+	assert.Nil(t, issues)
+	assert.IsType(t, &PatternError{}, err)
+}
+
+func TestValidate(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+
+	assert.Nil(t, m.Validate(`{"id": "@number@"}`))
+	assert.EqualError(
+		t,
+		m.Validate(`{"id": "@nubmer@"}`),
+		`pattern has lint issues: line 1, column 8: unknown pattern "@nubmer@" will be compared as a string at path: id`,
+	)
+	assert.IsType(t, &PatternError{}, m.Validate(`{`))
+}
@@ -0,0 +1,36 @@
+package gomatch
+
+import (
+	"errors"
+	"net"
+)
+
+var errNotMAC = errors.New("expected MAC address")
+
+// A MACMatcher matches hardware addresses in any form accepted by net.ParseMAC,
+// e.g. "00:00:5e:00:53:01", "00-00-5E-00-53-01" or "0000.5e00.5301".
+type MACMatcher struct {
+	pattern string
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *MACMatcher) CanMatch(p interface{}) bool {
+	return isPattern(p, m.pattern)
+}
+
+// Match performs value matching against given pattern.
+func (m *MACMatcher) Match(p, v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, errNotMAC
+	}
+	if _, err := net.ParseMAC(s); err != nil {
+		return false, errNotMAC
+	}
+	return true, nil
+}
+
+// NewMACMatcher creates MACMatcher.
+func NewMACMatcher(pattern string) *MACMatcher {
+	return &MACMatcher{pattern}
+}
@@ -0,0 +1,41 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var macMatcherTests = []struct {
+	desc   string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match MAC address with colons", "00:00:5e:00:53:01", true, ""},
+	{"Should match MAC address with hyphens", "00-00-5E-00-53-01", true, ""},
+	{"Should match MAC address with dots", "0000.5e00.5301", true, ""},
+	{"Should not match invalid MAC address", "00:00:5e:00:53", false, "expected MAC address"},
+	{"Should not match number", 1., false, "expected MAC address"},
+}
+
+func TestMACMatcher(t *testing.T) {
+	pattern := "@pattern@"
+
+	for _, tt := range macMatcherTests {
+		m := NewMACMatcher(pattern)
+		assert.True(t, m.CanMatch(pattern), "expected to support pattern")
+
+		t.Logf(tt.desc)
+
+		ok, err := m.Match(pattern, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package gomatch
+
+import "errors"
+
+const patternNull = "@null@"
+
+var errNotNull = errors.New("expected null")
+
+// A NullMode defines how JSONMatcher treats null values of the pattern and missing keys.
+type NullMode int
+
+const (
+	// NullRequired makes null of the pattern match only an explicit null. It is the default.
+	NullRequired NullMode = iota
+	// NullOrMissing makes absent keys and null values equivalent: null of the pattern
+	// matches a missing key and a null of actual JSON matches a key missing in the pattern.
+	NullOrMissing
+)
+
+// A nullPattern is a "@null@" pattern of a compiled pattern, which overrides NullMode
+// for a single key:
+//
+//  "@null@"               // explicit null is required
+//  "@null@.orMissing()"   // null or a missing key
+type nullPattern struct {
+	orMissing bool
+}
+
+// parseNullPattern parses s if it is a "@null@" pattern. It returns nil if s is not such pattern.
+func parseNullPattern(s string) *nullPattern {
+	parsed, ok := matchPattern(s, patternNull)
+	if !ok || parsed.hasArgs {
+		return nil
+	}
+	switch {
+	case len(parsed.expanders) == 0:
+		return &nullPattern{}
+	case len(parsed.expanders) == 1 && parsed.expanders[0].name == "orMissing" && parsed.expanders[0].args == "":
+		return &nullPattern{orMissing: true}
+	}
+	return nil
+}
+
+// allowsMissing checks if expected value of a key is satisfied by a missing key.
+func (c *config) allowsMissing(expected interface{}) bool {
+	switch e := expected.(type) {
+	case *nullPattern:
+		return e.orMissing
+	case nil:
+		return c.nullMode == NullOrMissing
+	}
+	return false
+}
+
+// allowsUnexpected checks if actual value of a key missing in the pattern is allowed.
+func (c *config) allowsUnexpected(actual interface{}) bool {
+	return actual == nil && c.nullMode == NullOrMissing
+}
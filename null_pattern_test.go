@@ -0,0 +1,72 @@
+package gomatch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var nullModeTests = []struct {
+	desc   string
+	mode   NullMode
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should require explicit null by default", NullRequired, `{"a": null}`, `{}`, false, `expected key "a"`},
+	{"Should not allow unexpected null by default", NullRequired, `{}`, `{"a": null}`, false, "unexpected key at path: a"},
+	{"Should match missing key with null", NullOrMissing, `{"a": null, "b": 1}`, `{"b": 1}`, true, ""},
+	{"Should match unexpected null", NullOrMissing, `{"b": 1}`, `{"a": null, "b": 1}`, true, ""},
+	{"Should still match explicit null", NullOrMissing, `{"a": null}`, `{"a": null}`, true, ""},
+	{"Should not match other unexpected value", NullOrMissing, `{}`, `{"a": 0}`, false, "unexpected key at path: a"},
+	{"Should not match missing key with other value", NullOrMissing, `{"a": 0}`, `{}`, false, `expected key "a"`},
+	{"Should require explicit null for a key", NullOrMissing, `{"a": "@null@"}`, `{}`, false, `expected key "a"`},
+	{"Should match explicit null for a key", NullOrMissing, `{"a": "@null@"}`, `{"a": null}`, true, ""},
+	{"Should not match other value with null pattern", NullOrMissing, `{"a": "@null@"}`, `{"a": ""}`, false, "expected null at path: a"},
+	{"Should match missing key for a key", NullRequired, `{"a": "@null@.orMissing()", "b": 1}`, `{"b": 1}`, true, ""},
+	{"Should match null for a key", NullRequired, `{"a": "@null@.orMissing()"}`, `{"a": null}`, true, ""},
+	{"Should not match other value for a key", NullRequired, `{"a": "@null@.orMissing()"}`, `{"a": 1}`, false, "expected null at path: a"},
+	{"Should match nested objects", NullOrMissing, `{"user": {"deleted_at": null}}`, `{"user": {}}`, true, ""},
+}
+
+func TestNullMode(t *testing.T) {
+	for _, tt := range nullModeTests {
+		t.Logf(tt.desc)
+		m := NewDefaultJSONMatcher(WithNullMode(tt.mode))
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+
+		p, err := m.Compile(tt.p)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		ok, err = p.MatchStream(strings.NewReader(tt.v))
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestNullPatternLint(t *testing.T) {
+	issues, err := NewDefaultJSONMatcher().Lint(`{"a": "@null@", "b": "@null@.orMissing()", "c": "@null@.other()"}`)
+
+	assert.Nil(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, `line 1, column 49: unknown pattern "@null@.other()" will be compared as a string at path: c`, issues[0].String())
+	}
+}
@@ -1,30 +1,331 @@
 package gomatch
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
 
-var errNotNumber = errors.New("expected number")
+var (
+	errNotNumber   = errors.New("expected number")
+	errNumberRange = errors.New("number out of range")
+)
 
-// A NumberMatcher matches float64.
-// It expects float64 because json.Unmarshal uses float64 by default for numbers.
+// A NumberMatcher matches json.Number and float64.
+// JSONMatcher decodes numbers as json.Number to preserve their precision
+// unless it uses NumberPrecisionFloat64 in which case numbers are float64.
+//
+// Supported expanders:
+//
+//  @number@.equals(5)
+//  @number@.approx(12.5, 0.01)         // 12.49 <= v <= 12.51
+//  @number@.approxRel(200, 0.05)       // within 5% of 200
+//  @number@.between(1, 10)             // 1 <= v <= 10
+//  @number@.betweenExclusive(0, 1)     // 0 < v < 1
+//
+// Numbers are compared exactly, without floating point rounding.
 type NumberMatcher struct {
 	pattern string
 }
 
+var numberExpanders = expanderSet{
+	"equals":           numberEquals,
+	"approx":           numberApprox(false),
+	"approxRel":        numberApprox(true),
+	"between":          numberBetween(false),
+	"betweenExclusive": numberBetween(true),
+}
+
 // CanMatch returns true if pattern p can be handled
 func (m *NumberMatcher) CanMatch(p interface{}) bool {
-	return isPattern(p, m.pattern)
+	_, ok := numberExpanders.build(p, m.pattern)
+	return ok
 }
 
 // Match performs value matching against given pattern.
 func (m *NumberMatcher) Match(p, v interface{}) (bool, error) {
-	_, ok := v.(float64)
-	if ok {
-		return ok, nil
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *NumberMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return numberExpanders.compile(p, m.pattern, validateNumber)
+}
+
+func (m *NumberMatcher) patternExpanders() expanderSet {
+	return numberExpanders
+}
+
+func validateNumber(v interface{}) error {
+	if !isNumber(v) {
+		return errNotNumber
+	}
+	return nil
+}
+
+func numberEquals(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	n, ok := args[0].number()
+	if !ok {
+		return nil, errNotNumber
+	}
+	return func(v interface{}) error {
+		if !numbersEqual(n, toNumber(v)) {
+			return fmt.Errorf("expected number equal to %s", n)
+		}
+		return nil
+	}, nil
+}
+
+// numberApprox checks if a number differs from the expected one at most by tolerance.
+// Relative tolerance is a fraction of the expected number.
+func numberApprox(relative bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		r, err := ratArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		expected, tolerance := r[0], r[1]
+		if tolerance.Sign() < 0 {
+			return nil, errInvalidArgs
+		}
+		msg := fmt.Sprintf("expected number within %s of %s", args[1], args[0])
+		if relative {
+			tolerance = new(big.Rat).Mul(tolerance, new(big.Rat).Abs(expected))
+			msg = fmt.Sprintf("expected number within relative tolerance %s of %s", args[1], args[0])
+		}
+		return func(v interface{}) error {
+			n, err := parseRat(toNumber(v).String())
+			if err != nil {
+				return err
+			}
+			diff := new(big.Rat).Sub(n, expected)
+			if diff.Abs(diff).Cmp(tolerance) > 0 {
+				return errors.New(msg)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// numberBetween checks if a number is within a range.
+func numberBetween(exclusive bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		r, err := ratArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		low, high := r[0], r[1]
+		if low.Cmp(high) > 0 {
+			return nil, errInvalidArgs
+		}
+		msg := fmt.Sprintf("expected number between %s and %s inclusive", args[0], args[1])
+		if exclusive {
+			msg = fmt.Sprintf("expected number between %s and %s exclusive", args[0], args[1])
+		}
+		return func(v interface{}) error {
+			n, err := parseRat(toNumber(v).String())
+			if err != nil {
+				return err
+			}
+			cmpLow, cmpHigh := n.Cmp(low), n.Cmp(high)
+			if cmpLow < 0 || cmpHigh > 0 || exclusive && (cmpLow == 0 || cmpHigh == 0) {
+				return errors.New(msg)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// ratArgs converts n number arguments to big.Rat.
+func ratArgs(args []patternArg, n int) ([]*big.Rat, error) {
+	if err := argsCount(args, n); err != nil {
+		return nil, err
+	}
+	rats := make([]*big.Rat, n)
+	for i, a := range args {
+		num, ok := a.number()
+		if !ok {
+			return nil, errNotNumber
+		}
+		r, err := parseRat(num.String())
+		if err != nil {
+			return nil, err
+		}
+		rats[i] = r
+	}
+	return rats, nil
+}
+
+// toNumber converts a number decoded from JSON to json.Number.
+func toNumber(v interface{}) json.Number {
+	if f, ok := v.(float64); ok {
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
 	}
-	return ok, errNotNumber
+	return v.(json.Number)
 }
 
 // NewNumberMatcher creates NumberMatcher.
 func NewNumberMatcher(pattern string) *NumberMatcher {
 	return &NumberMatcher{pattern}
 }
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case json.Number, float64:
+		return true
+	}
+	return false
+}
+
+// A decimal is an exact representation of a JSON number: 0.digits × 10^exp.
+// Digits have no leading and trailing zeros, zero has no digits.
+type decimal struct {
+	neg    bool
+	digits string
+	exp    int
+}
+
+// maxDecimalExp limits exponent so it can be safely adjusted by number of digits.
+const maxDecimalExp = 1 << 30
+
+func parseDecimal(s string) (decimal, bool) {
+	var d decimal
+	if strings.HasPrefix(s, "-") {
+		d.neg = true
+		s = s[1:]
+	}
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i > -1 {
+		mantissa, exponent = s[:i], s[i+1:]
+	}
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i > -1 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+	if intPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return d, false
+	}
+	if exponent != "" {
+		e, err := strconv.Atoi(exponent)
+		if err != nil {
+			numErr, ok := err.(*strconv.NumError)
+			if !ok || numErr.Err != strconv.ErrRange {
+				return d, false
+			}
+			e = maxDecimalExp
+			if strings.HasPrefix(exponent, "-") {
+				e = -maxDecimalExp
+			}
+		}
+		if e > maxDecimalExp {
+			e = maxDecimalExp
+		} else if e < -maxDecimalExp {
+			e = -maxDecimalExp
+		}
+		d.exp = e
+	}
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	d.exp += len(intPart) - (len(intPart+fracPart) - len(digits))
+	d.digits = strings.TrimRight(digits, "0")
+	if d.digits == "" {
+		return decimal{}, true
+	}
+	return d, true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// cmp compares d and o and returns -1 if d < o, 0 if d == o and +1 if d > o.
+func (d decimal) cmp(o decimal) int {
+	if d.neg != o.neg {
+		if d.neg {
+			return -1
+		}
+		return 1
+	}
+	c := d.cmpAbs(o)
+	if d.neg {
+		return -c
+	}
+	return c
+}
+
+func (d decimal) cmpAbs(o decimal) int {
+	switch {
+	case d.digits == "" && o.digits == "":
+		return 0
+	case d.digits == "":
+		return -1
+	case o.digits == "":
+		return 1
+	case d.exp != o.exp:
+		if d.exp < o.exp {
+			return -1
+		}
+		return 1
+	case d.digits < o.digits:
+		return -1
+	case d.digits > o.digits:
+		return 1
+	}
+	return 0
+}
+
+// numbersEqual compares two JSON numbers exactly.
+func numbersEqual(a, b json.Number) bool {
+	da, ok := parseDecimal(a.String())
+	if !ok {
+		return a == b
+	}
+	db, ok := parseDecimal(b.String())
+	if !ok {
+		return a == b
+	}
+	return da.cmp(db) == 0
+}
+
+// numbersWithin checks if two numbers differ at most by epsilon.
+func numbersWithin(a, b interface{}, epsilon *big.Rat) bool {
+	ra, err := parseRat(toNumber(a).String())
+	if err != nil {
+		return false
+	}
+	rb, err := parseRat(toNumber(b).String())
+	if err != nil {
+		return false
+	}
+	diff := new(big.Rat).Sub(ra, rb)
+	return diff.Abs(diff).Cmp(epsilon) <= 0
+}
+
+// maxRatExp limits exponent and number of digits of numbers converted to big.Rat,
+// so arithmetic on them is bounded.
+const maxRatExp = 1000
+
+// parseRat converts JSON number to big.Rat.
+func parseRat(s string) (*big.Rat, error) {
+	d, ok := parseDecimal(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	if d.exp > maxRatExp || d.exp < -maxRatExp || len(d.digits) > maxRatExp {
+		return nil, errNumberRange
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	return r, nil
+}
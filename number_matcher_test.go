@@ -1,6 +1,7 @@
 package gomatch
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,12 @@ var numberMatcherTests = []struct {
 		true,
 		"",
 	},
+	{
+		"Should match json.Number",
+		json.Number("12345678901234567890"),
+		true,
+		"",
+	},
 	{
 		"Should not match string",
 		"100",
@@ -53,3 +60,92 @@ func TestNumberMatcher(t *testing.T) {
 		}
 	}
 }
+
+var numberExpanderTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match equal number", "@number@.equals(1.50)", json.Number("1.5"), true, ""},
+	{"Should match equal float64", "@number@.equals(2)", 2., true, ""},
+	{"Should not match different number", "@number@.equals(2)", json.Number("2.01"), false, "expected number equal to 2"},
+	{"Should check type before expanders", "@number@.equals(2)", "2", false, "expected number"},
+	{"Should match approximate number", "@number@.approx(12.5, 0.01)", json.Number("12.51"), true, ""},
+	{"Should match approximate float64", "@number@.approx(0.3, 0.001)", 0.30000000000000004, true, ""},
+	{"Should not match number out of tolerance", "@number@.approx(12.5, 0.01)", json.Number("12.4899"), false, "expected number within 0.01 of 12.5"},
+	{"Should match number within relative tolerance", "@number@.approxRel(-200, 0.05)", json.Number("-190"), true, ""},
+	{"Should not match number out of relative tolerance", "@number@.approxRel(200, 0.05)", json.Number("189.99"), false, "expected number within relative tolerance 0.05 of 200"},
+	{"Should match number in range", "@number@.between(1, 10)", json.Number("10"), true, ""},
+	{"Should not match number out of range", "@number@.between(1, 10)", json.Number("10.0001"), false, "expected number between 1 and 10 inclusive"},
+	{"Should match number in exclusive range", "@number@.betweenExclusive(0, 1)", json.Number("0.5"), true, ""},
+	{"Should not match range bound", "@number@.betweenExclusive(0, 1)", json.Number("1"), false, "expected number between 0 and 1 exclusive"},
+	{"Should report huge numbers", "@number@.between(0, 1)", json.Number("1e10000"), false, "number out of range"},
+}
+
+func TestNumberMatcherExpanders(t *testing.T) {
+	m := NewNumberMatcher("@number@")
+	for _, tt := range numberExpanderTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestNumberMatcherInvalidExpanders(t *testing.T) {
+	m := NewNumberMatcher("@number@")
+	for _, p := range []string{"@number@.equals()", `@number@.equals("1")`, "@number@.equals(1, 2)", "@number@.unknown(1)", "@number@(1)",
+		"@number@.approx(1)", "@number@.approx(1, -0.1)", `@number@.between("1", 2)`, "@number@.between(2, 1)"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
+
+var numbersEqualTests = []struct {
+	a, b  string
+	equal bool
+}{
+	{"1", "1", true},
+	{"1", "1.0", true},
+	{"100", "1e2", true},
+	{"0.0012", "12E-4", true},
+	{"0", "-0.0", true},
+	{"-1.5", "-1.50", true},
+	{"9007199254740993", "9007199254740992", false},
+	{"0.10000000000000000001", "0.1", false},
+	{"1", "-1", false},
+	{"12", "1.2", false},
+	{"1e1000000000000", "1e1000000000001", true},
+}
+
+func TestNumbersEqual(t *testing.T) {
+	for _, tt := range numbersEqualTests {
+		assert.Equal(t, tt.equal, numbersEqual(json.Number(tt.a), json.Number(tt.b)), "%s == %s", tt.a, tt.b)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	numbers := []string{"-1e3", "-12.5", "-1", "-0.001", "0", "0.001", "0.0011", "1", "9.99", "10", "1e10"}
+	for i := range numbers {
+		for j := range numbers {
+			a, _ := parseDecimal(numbers[i])
+			b, _ := parseDecimal(numbers[j])
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, a.cmp(b), "%s cmp %s", numbers[i], numbers[j])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package gomatch
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var errNotObjectID = errors.New("expected ObjectID")
+
+// An ObjectIDMatcher matches MongoDB ObjectIDs, e.g. "507f1f77bcf86cd799439011".
+//
+// Supported expanders check the embedded timestamp:
+//
+//  @objectid@.after("2020-01-01T00:00:00Z")
+//  @objectid@.before("2030-01-01T00:00:00Z")
+//  @objectid@.within("24h")
+type ObjectIDMatcher struct {
+	*timestampIDMatcher
+}
+
+// NewObjectIDMatcher creates ObjectIDMatcher.
+func NewObjectIDMatcher(pattern string) *ObjectIDMatcher {
+	return &ObjectIDMatcher{newTimestampIDMatcher(pattern, objectIDTimestamp, func(m *timestampIDMatcher) ValueMatcher {
+		return &ObjectIDMatcher{m}
+	})}
+}
+
+// objectIDTimestamp returns a timestamp in Unix seconds stored in the first 4 bytes of ObjectID.
+func objectIDTimestamp(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 24 {
+		return time.Time{}, errNotObjectID
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return time.Time{}, errNotObjectID
+	}
+	seconds := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	return time.Unix(seconds, 0), nil
+}
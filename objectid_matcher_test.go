@@ -0,0 +1,44 @@
+package gomatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var objectIDMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match ObjectID", "@objectid@", "507f1f77bcf86cd799439011", true, ""},
+	{"Should match upper case ObjectID", "@objectid@", "507F1F77BCF86CD799439011", true, ""},
+	{"Should not match invalid character", "@objectid@", "507f1f77bcf86cd79943901g", false, "expected ObjectID"},
+	{"Should not match too short ObjectID", "@objectid@", "507f1f77bcf86cd79943901", false, "expected ObjectID"},
+	{"Should not match number", "@objectid@", 1., false, "expected ObjectID"},
+	{"Should match timestamp in range", `@objectid@.after("2012-01-01T00:00:00Z").before("2013-01-01T00:00:00Z")`, "507f1f77bcf86cd799439011", true, ""},
+	{"Should not match timestamp out of range", `@objectid@.before("2012-10-17T21:13:27Z")`, "507f1f77bcf86cd799439011", false, "expected timestamp before 2012-10-17T21:13:27Z, got 2012-10-17T21:13:27Z"},
+	{"Should not match timestamp far from now", `@objectid@.within("1s")`, "507f1f77bcf86cd799439011", false, "expected timestamp within 1s from now, got 2012-10-17T21:13:27Z"},
+}
+
+func TestObjectIDMatcher(t *testing.T) {
+	m := NewObjectIDMatcher("@objectid@")
+	m.SetClock(func() time.Time { return time.Date(2012, 10, 17, 21, 13, 29, 0, time.UTC) })
+	for _, tt := range objectIDMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
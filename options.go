@@ -0,0 +1,263 @@
+package gomatch
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// An Option configures JSONMatcher created with New.
+type Option func(*config)
+
+// config holds JSONMatcher settings. It is copied to every CompiledPattern,
+// so changing the matcher does not affect patterns compiled before.
+type config struct {
+	valueMatcher        ValueMatcher
+	numberPrecision     NumberPrecision
+	unorderedArrays     bool
+	maxErrors           int
+	openDelimiter       string
+	closeDelimiter      string
+	registry            *Registry
+	numberEpsilon       *big.Rat
+	coerceTypes         bool
+	nullMode            NullMode
+	strictKeyOrder      bool
+	detectDuplicateKeys bool
+	ignoredPaths        []string
+	clock               Clock
+}
+
+func (c *config) useNumber() bool {
+	return c.numberPrecision == NumberPrecisionExact
+}
+
+// A valuePattern is a value pattern resolved to a value matcher handling it.
+type valuePattern struct {
+	matcher ValueMatcher
+	pattern interface{}
+	// expanders are handled by a registry after the matcher
+	expanders []patternCall
+	// check is the pattern prepared by a patternCompiler, it is used instead of the matcher
+	check func(v interface{}) error
+}
+
+func newValuePattern(m ValueMatcher, p interface{}, expanders []patternCall) *valuePattern {
+	vp := &valuePattern{matcher: m, pattern: p, expanders: expanders}
+	if c, ok := m.(patternCompiler); ok {
+		vp.check, _ = c.compilePattern(p)
+	}
+	return vp
+}
+
+// resolvePattern finds a value matcher handling pattern p. It returns nil if p is not a pattern.
+// Chained matchers are searched for the one handling p, so the chain is not searched again
+// on every match.
+//
+// If p is not supported by value matcher as a whole, it tries to handle trailing expanders
+// with registry and the rest of pattern with value matcher.
+func (c *config) resolvePattern(p interface{}) *valuePattern {
+	if m := findMatcher(c.valueMatcher, p); m != nil {
+		return newValuePattern(m, p, nil)
+	}
+	if c.registry == nil {
+		return nil
+	}
+	if c.registry.CanMatch(p) {
+		return newValuePattern(c.registry, p, nil)
+	}
+	s, ok := p.(string)
+	if !ok {
+		return nil
+	}
+	parsed, ok := parsePattern(s)
+	if !ok {
+		return nil
+	}
+	for i := len(parsed.expanders) - 1; i >= 0; i-- {
+		if !c.registry.canExpand(parsed.expanders[i:]) {
+			break
+		}
+		head := parsed.head(s, i)
+		if m := findMatcher(c.valueMatcher, head); m != nil {
+			return newValuePattern(m, head, parsed.expanders[i:])
+		}
+	}
+	return nil
+}
+
+// findMatcher returns a value matcher handling pattern p, or one of matchers chained by m.
+// It returns nil if none of them can handle p.
+func findMatcher(m ValueMatcher, p interface{}) ValueMatcher {
+	if c, ok := m.(*ChainMatcher); ok {
+		for _, m := range c.matchers {
+			if m.CanMatch(p) {
+				return findMatcher(m, p)
+			}
+		}
+		return nil
+	}
+	if m.CanMatch(p) {
+		return m
+	}
+	return nil
+}
+
+// match matches value v with the pattern.
+func (p *valuePattern) match(r *Registry, v interface{}) error {
+	if p.check != nil {
+		if err := p.check(v); err != nil {
+			return err
+		}
+	} else if _, err := p.matcher.Match(p.pattern, v); err != nil {
+		return err
+	}
+	if len(p.expanders) > 0 {
+		return r.expand(p.expanders, v)
+	}
+	return nil
+}
+
+// New creates JSONMatcher configured with given options.
+// Without any options it matches values by comparison only, without any value patterns.
+//
+//  m := gomatch.New(
+//  	gomatch.WithMatchers(gomatch.DefaultMatchers()...),
+//  	gomatch.WithUnorderedArrays(),
+//  	gomatch.WithMaxErrors(10),
+//  )
+func New(opts ...Option) *JSONMatcher {
+	m := &JSONMatcher{config{
+		valueMatcher: NewChainMatcher(nil),
+		maxErrors:    1,
+	}}
+	for _, opt := range opts {
+		opt(&m.config)
+	}
+	if m.clock != nil {
+		m.valueMatcher = withClock(m.valueMatcher, m.clock)
+	}
+	return m
+}
+
+// WithMatchers sets value matchers used to handle value patterns.
+// Multiple matchers are chained with ChainMatcher.
+// It replaces matchers set before, e.g. by NewDefaultJSONMatcher.
+func WithMatchers(matchers ...ValueMatcher) Option {
+	return func(c *config) {
+		if len(matchers) == 1 {
+			c.valueMatcher = matchers[0]
+			return
+		}
+		c.valueMatcher = NewChainMatcher(matchers)
+	}
+}
+
+// WithStrictArrays makes arrays match only if their elements are in the same order
+// as in the pattern. It is the default.
+func WithStrictArrays() Option {
+	return func(c *config) {
+		c.unorderedArrays = false
+	}
+}
+
+// WithUnorderedArrays makes arrays match regardless of their elements order.
+// Every pattern element has to match a different actual element.
+// The unbounded pattern "@...@" allows any extra elements wherever it is placed.
+func WithUnorderedArrays() Option {
+	return func(c *config) {
+		c.unorderedArrays = true
+	}
+}
+
+// WithMaxErrors sets how many mismatches are reported before matching stops.
+// By default matching stops at the first mismatch. Use 0 to report all mismatches.
+// When more than one mismatch is found the returned error is a *MismatchError.
+func WithMaxErrors(n int) Option {
+	return func(c *config) {
+		c.maxErrors = n
+	}
+}
+
+// WithNumberPrecision sets how numbers are decoded and compared.
+func WithNumberPrecision(p NumberPrecision) Option {
+	return func(c *config) {
+		c.numberPrecision = p
+	}
+}
+
+// WithNumberEpsilon makes numbers given in the pattern match actual numbers
+// differing at most by epsilon, e.g. with WithNumberEpsilon(0.001) 12.5 matches 12.5004.
+// It does not affect number patterns, see NumberMatcher expanders for tolerance of patterns.
+func WithNumberEpsilon(epsilon float64) Option {
+	return func(c *config) {
+		c.numberEpsilon = nil
+		if epsilon > 0 {
+			// the shortest decimal form is used, so 0.3 is 3/10 and not the nearest float64
+			c.numberEpsilon, _ = new(big.Rat).SetString(strconv.FormatFloat(epsilon, 'g', -1, 64))
+		}
+	}
+}
+
+// WithClock sets a clock of value matchers checking timestamps relative to now,
+// e.g. ULIDMatcher. The matchers are copied, so matchers given to WithMatchers
+// are not changed and may be shared by other JSONMatchers. It panics if clock is nil.
+//
+//  m := gomatch.NewDefaultJSONMatcher(gomatch.WithClock(func() time.Time { return now }))
+func WithClock(clock Clock) Option {
+	mustClock(clock)
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// WithTypeCoercion makes strings of actual JSON match values of other types
+// when they can be converted: numeric strings match numbers, e.g. "42" matches 42,
+// "true" and "false" match booleans, and empty strings and "null" match null.
+// Value patterns rejecting a string as a number or a boolean are retried with the converted value,
+// e.g. "42" matches "@number@", while mismatches of other patterns are reported as they are.
+// Mismatches of converted values note that coercion was attempted.
+func WithTypeCoercion() Option {
+	return func(c *config) {
+		c.coerceTypes = true
+	}
+}
+
+// WithNullMode sets how null values of the pattern and missing keys are matched.
+// The mode may be overridden for a single key with "@null@" requiring an explicit null
+// and "@null@.orMissing()" matching null or a missing key.
+func WithNullMode(mode NullMode) Option {
+	return func(c *config) {
+		c.nullMode = mode
+	}
+}
+
+// WithStrictKeyOrder makes objects match only if keys of actual JSON present in the pattern
+// are in the same order as in the pattern. Other keys, e.g. allowed by "@...@", may be anywhere.
+// Both JSONs are decoded preserving key order, which is slower. Objects of actual JSON given
+// as Go maps to MatchValue are marshalled with sorted keys.
+func WithStrictKeyOrder() Option {
+	return func(c *config) {
+		c.strictKeyOrder = true
+	}
+}
+
+// WithDuplicateKeyDetection makes duplicate object keys, which encoding/json silently
+// drops keeping the last value, invalid. A pattern with duplicate keys fails to compile
+// and duplicate keys of actual JSON are reported as mismatches at their paths.
+// MatchStream detects duplicates only in objects which are not matched as a whole,
+// e.g. by a value pattern or "@...@" array elements.
+func WithDuplicateKeyDetection() Option {
+	return func(c *config) {
+		c.detectDuplicateKeys = true
+	}
+}
+
+// WithIgnoredPaths makes values of actual JSON at given paths ignored, as if the pattern
+// had "@wildcard@" there, and their keys optional. Paths are given as JSONPath, e.g.
+// "$.items[*].updated_at" or "$..request_id", or as JSON Pointer, e.g. "/items/0/updated_at".
+// Invalid paths are reported when a pattern is compiled.
+func WithIgnoredPaths(paths ...string) Option {
+	return func(c *config) {
+		c.ignoredPaths = append(append([]string(nil), c.ignoredPaths...), paths...)
+	}
+}
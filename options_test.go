@@ -0,0 +1,196 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var optionsTests = []struct {
+	desc   string
+	opts   []Option
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{
+		"Should compare values only if no matchers given",
+		nil,
+		`{"id": "@number@"}`,
+		`{"id": 1}`,
+		false,
+		"types are not equal at path: id",
+	},
+	{
+		"Should use given matchers",
+		[]Option{WithMatchers(NewNumberMatcher("@number@"), NewStringMatcher("@string@"))},
+		`{"id": "@number@", "name": "@string@"}`,
+		`{"id": 1, "name": "John"}`,
+		true,
+		"",
+	},
+	{
+		"Should match arrays in order by default",
+		[]Option{WithMatchers(DefaultMatchers()...)},
+		`[1, 2, 3]`,
+		`[3, 1, 2]`,
+		false,
+		"values are not equal at path: [0]",
+	},
+	{
+		"Should match unordered arrays",
+		[]Option{WithUnorderedArrays()},
+		`[1, 2, {"id": 3}]`,
+		`[{"id": 3}, 1, 2]`,
+		true,
+		"",
+	},
+	{
+		"Should assign unordered array elements to different actual elements",
+		[]Option{WithMatchers(DefaultMatchers()...), WithUnorderedArrays()},
+		`["@number@", 1]`,
+		`[1, 2]`,
+		true,
+		"",
+	},
+	{
+		"Should fail if unordered array element has no matching element",
+		[]Option{WithUnorderedArrays()},
+		`[1, 2, 3]`,
+		`[3, 1, 1]`,
+		false,
+		"no matching array element at path: [1]",
+	},
+	{
+		"Should fail if unordered arrays sizes are not equal",
+		[]Option{WithUnorderedArrays()},
+		`[1, 2]`,
+		`[2, 1, 3]`,
+		false,
+		"arrays sizes are not equal",
+	},
+	{
+		"Should allow extra elements in unordered arrays if unbounded pattern is used",
+		[]Option{WithUnorderedArrays()},
+		`[3, "@...@"]`,
+		`[1, 2, 3]`,
+		true,
+		"",
+	},
+	{
+		"Should use the last of array options",
+		[]Option{WithUnorderedArrays(), WithStrictArrays()},
+		`[1, 2]`,
+		`[2, 1]`,
+		false,
+		"values are not equal at path: [0]",
+	},
+	{
+		"Should report only first mismatch by default",
+		nil,
+		`{"a": 1, "b": 2, "c": 3}`,
+		`{"a": 0, "b": 0, "c": 3}`,
+		false,
+		"values are not equal at path: a",
+	},
+	{
+		"Should report up to max errors",
+		[]Option{WithMaxErrors(2)},
+		`{"a": 1, "b": [1, 2], "c": 3, "d": 4}`,
+		`{"a": 0, "b": [1, 0], "c": 0, "e": 4}`,
+		false,
+		"2 mismatches: values are not equal at path: a; values are not equal at path: b[1]",
+	},
+	{
+		"Should report all mismatches",
+		[]Option{WithMaxErrors(0)},
+		`{"a": 1, "b": [1, 2], "c": 3, "d": 4}`,
+		`{"a": 0, "b": [1, 0], "c": 0, "e": 4}`,
+		false,
+		`5 mismatches: values are not equal at path: a; values are not equal at path: b[1]; ` +
+			`values are not equal at path: c; expected key "d"; unexpected key at path: e`,
+	},
+	{
+		"Should use number precision",
+		[]Option{WithNumberPrecision(NumberPrecisionFloat64)},
+		`9007199254740993`,
+		`9007199254740992`,
+		true,
+		"",
+	},
+	{
+		"Should compare numbers with epsilon",
+		[]Option{WithNumberEpsilon(0.001)},
+		`{"a": 12.5, "b": [0.3]}`,
+		`{"a": 12.501, "b": [0.2999]}`,
+		true,
+		"",
+	},
+	{
+		"Should report numbers differing more than epsilon",
+		[]Option{WithNumberEpsilon(0.001)},
+		`{"a": 12.5}`,
+		`{"a": 12.5011}`,
+		false,
+		"values are not equal at path: a",
+	},
+	{
+		"Should match numbers differing exactly by epsilon",
+		[]Option{WithNumberEpsilon(0.3)},
+		`{"a": 1.0, "b": 1.3}`,
+		`{"a": 1.3, "b": 1.0}`,
+		true,
+		"",
+	},
+	{
+		"Should match integer and number differing exactly by epsilon",
+		[]Option{WithNumberEpsilon(0.7)},
+		`1`,
+		`1.7`,
+		true,
+		"",
+	},
+	{
+		"Should compare float64 numbers with epsilon",
+		[]Option{WithNumberEpsilon(0.01), WithNumberPrecision(NumberPrecisionFloat64)},
+		`0.3`,
+		`0.301`,
+		true,
+		"",
+	},
+}
+
+func TestOptions(t *testing.T) {
+	for _, tt := range optionsTests {
+		t.Logf(tt.desc)
+
+		ok, err := New(tt.opts...).Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestMismatchError(t *testing.T) {
+	_, err := New(WithMaxErrors(0)).Match(`[1, 2]`, `[0, 0]`)
+
+	merr, ok := err.(*MismatchError)
+	if assert.True(t, ok, "expected *MismatchError") {
+		assert.Len(t, merr.Errors, 2)
+	}
+}
+
+func TestNewDefaultJSONMatcherWithOptions(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithUnorderedArrays())
+
+	ok, err := m.Match(`["@string@", "@number@"]`, `[1, "a"]`)
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,291 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errInvalidArgs = errors.New("invalid pattern arguments")
+
+// A patternCall is a named part of a pattern with arguments, e.g. an expander `.between(1, 10)`.
+type patternCall struct {
+	name string
+	// args is a raw text between parentheses
+	args string
+}
+
+// A parsedPattern is a value pattern split into its parts.
+//
+// Pattern may have arguments given inside or after delimiters, and may be followed
+// by any number of expanders:
+//
+//  @name@
+//  @name(args)@.expander(args)
+//  @name@(args).expander(args).expander()
+type parsedPattern struct {
+	name      string
+	args      string
+	hasArgs   bool
+	expanders []patternCall
+	// ends holds offsets where the base pattern and every expander end
+	ends []int
+}
+
+// head returns a pattern text without expanders starting from i-th one.
+func (p *parsedPattern) head(s string, i int) string {
+	return s[:p.ends[i]]
+}
+
+// parsePattern parses pattern s having default delimiters.
+func parsePattern(s string) (*parsedPattern, bool) {
+	sc := &patternScanner{s: s}
+	if !sc.consume(defaultDelimiter) {
+		return nil, false
+	}
+	p := &parsedPattern{name: sc.ident()}
+	if p.name == "" {
+		return nil, false
+	}
+	if sc.peek('(') {
+		args, ok := sc.args()
+		if !ok {
+			return nil, false
+		}
+		p.args, p.hasArgs = args, true
+	}
+	if !sc.consume(defaultDelimiter) {
+		return nil, false
+	}
+	if !p.hasArgs && sc.peek('(') {
+		args, ok := sc.args()
+		if !ok {
+			return nil, false
+		}
+		p.args, p.hasArgs = args, true
+	}
+	p.ends = append(p.ends, sc.pos)
+	if !sc.expanders(p) {
+		return nil, false
+	}
+	return p, true
+}
+
+// matchPattern parses p if it is given base pattern optionally followed
+// by arguments and expanders. The base pattern may have any form, e.g. "@number@",
+// but arguments inside delimiters are supported only for "@name@" form.
+func matchPattern(p interface{}, base string) (*parsedPattern, bool) {
+	s, ok := p.(string)
+	if !ok || !strings.HasPrefix(s, base) && !strings.HasPrefix(s, strings.TrimSuffix(base, defaultDelimiter)+"(") {
+		return nil, false
+	}
+	if s == base {
+		return &parsedPattern{ends: []int{len(s)}}, true
+	}
+	if strings.HasPrefix(s, base) {
+		sc := &patternScanner{s: s, pos: len(base)}
+		parsed := &parsedPattern{}
+		if sc.peek('(') {
+			args, ok := sc.args()
+			if !ok {
+				return nil, false
+			}
+			parsed.args, parsed.hasArgs = args, true
+		}
+		parsed.ends = append(parsed.ends, sc.pos)
+		if !sc.expanders(parsed) {
+			return nil, false
+		}
+		return parsed, true
+	}
+	parsed, ok := parsePattern(s)
+	if !ok || defaultDelimiter+parsed.name+defaultDelimiter != base {
+		return nil, false
+	}
+	return parsed, true
+}
+
+type patternScanner struct {
+	s   string
+	pos int
+}
+
+func (sc *patternScanner) consume(prefix string) bool {
+	if strings.HasPrefix(sc.s[sc.pos:], prefix) {
+		sc.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (sc *patternScanner) peek(c byte) bool {
+	return sc.pos < len(sc.s) && sc.s[sc.pos] == c
+}
+
+func (sc *patternScanner) ident() string {
+	start := sc.pos
+	for sc.pos < len(sc.s) && isIdentByte(sc.s[sc.pos]) {
+		sc.pos++
+	}
+	return sc.s[start:sc.pos]
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// args reads arguments in parentheses and returns text between them.
+func (sc *patternScanner) args() (string, bool) {
+	end := closingParen(sc.s, sc.pos)
+	if end < 0 {
+		return "", false
+	}
+	args := sc.s[sc.pos+1 : end]
+	sc.pos = end + 1
+	return args, true
+}
+
+// expanders reads expanders till the end of pattern.
+func (sc *patternScanner) expanders(p *parsedPattern) bool {
+	for sc.pos < len(sc.s) {
+		if !sc.consume(".") {
+			return false
+		}
+		name := sc.ident()
+		if name == "" || !sc.peek('(') {
+			return false
+		}
+		args, ok := sc.args()
+		if !ok {
+			return false
+		}
+		p.expanders = append(p.expanders, patternCall{name, args})
+		p.ends = append(p.ends, sc.pos)
+	}
+	return true
+}
+
+// closingParen returns index of a parenthesis closing the one at index start.
+// It skips brackets and JSON strings, so arguments may contain any JSON values.
+func closingParen(s string, start int) int {
+	end := closingBracket(s, start)
+	if end < 0 || s[end] != ')' {
+		return -1
+	}
+	return end
+}
+
+// closingQuote returns index of a quote closing JSON string starting at index start.
+func closingQuote(s string, start int) int {
+	return closingQuoteChar(s, start, '"')
+}
+
+// closingQuoteChar returns index of a given quote closing a string starting at index start.
+func closingQuoteChar(s string, start int, quote byte) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// A patternArg is a single argument of a pattern or an expander.
+// It holds a raw JSON value, e.g. `"desc"` or `12.5`.
+type patternArg string
+
+// String returns string value of JSON string argument or raw text of other arguments.
+func (a patternArg) String() string {
+	if a.isString() {
+		var s string
+		if err := json.Unmarshal([]byte(a), &s); err == nil {
+			return s
+		}
+	}
+	return string(a)
+}
+
+func (a patternArg) isString() bool {
+	return strings.HasPrefix(string(a), `"`)
+}
+
+// number returns value of JSON number argument.
+func (a patternArg) number() (json.Number, bool) {
+	if _, ok := parseDecimal(string(a)); !ok {
+		return "", false
+	}
+	return json.Number(a), true
+}
+
+// parseArgs splits raw arguments text into arguments.
+// Every argument has to be a valid JSON value.
+func parseArgs(raw string) ([]patternArg, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var args []patternArg
+	start := 0
+	for i := 0; i <= len(raw); i++ {
+		if i < len(raw) {
+			switch raw[i] {
+			case '(', '[', '{':
+				i = closingBracket(raw, i)
+				if i < 0 {
+					return nil, errInvalidArgs
+				}
+				continue
+			case '"':
+				i = closingQuote(raw, i)
+				if i < 0 {
+					return nil, errInvalidArgs
+				}
+				continue
+			case ',':
+			default:
+				continue
+			}
+		}
+		arg := strings.TrimSpace(raw[start:i])
+		if !json.Valid([]byte(arg)) {
+			return nil, errInvalidArgs
+		}
+		args = append(args, patternArg(arg))
+		start = i + 1
+	}
+	return args, nil
+}
+
+// closingBracket returns index of a bracket closing the one at index start.
+// It skips strings in double and single quotes, the latter are used by expressions.
+func closingBracket(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'':
+			i = closingQuoteChar(s, i, s[i])
+			if i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// argsToStrings converts arguments to strings, see patternArg.String.
+func argsToStrings(args []patternArg) []string {
+	strs := make([]string, len(args))
+	for i, a := range args {
+		strs[i] = a.String()
+	}
+	return strs
+}
@@ -0,0 +1,106 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parsePatternTests = []struct {
+	desc      string
+	p         string
+	ok        bool
+	name      string
+	args      string
+	expanders []patternCall
+}{
+	{"Should parse simple pattern", "@number@", true, "number", "", nil},
+	{"Should parse arguments inside delimiters", `@enum("a", "b")@`, true, "enum", `"a", "b"`, nil},
+	{"Should parse arguments after delimiters", `@json@({"id": "@number@"})`, true, "json", `{"id": "@number@"}`, nil},
+	{
+		"Should parse expanders",
+		`@number@.between(1, 10).approx(5, 0.1)`,
+		true,
+		"number",
+		"",
+		[]patternCall{{"between", "1, 10"}, {"approx", "5, 0.1"}},
+	},
+	{
+		"Should parse expanders with strings containing parentheses",
+		`@string@.contains(")").startsWith("(")`,
+		true,
+		"string",
+		"",
+		[]patternCall{{"contains", `")"`}, {"startsWith", `"("`}},
+	},
+	{"Should not parse pattern without closing delimiter", "@number", false, "", "", nil},
+	{"Should not parse pattern without name", "@@", false, "", "", nil},
+	{"Should not parse expander without parentheses", "@number@.positive", false, "", "", nil},
+	{"Should not parse unclosed arguments", "@number@.between(1, 10", false, "", "", nil},
+	{"Should not parse pattern followed by text", "@number@ is a number", false, "", "", nil},
+}
+
+func TestParsePattern(t *testing.T) {
+	for _, tt := range parsePatternTests {
+		t.Logf(tt.desc)
+
+		parsed, ok := parsePattern(tt.p)
+
+		assert.Equal(t, tt.ok, ok)
+		if tt.ok {
+			assert.Equal(t, tt.name, parsed.name)
+			assert.Equal(t, tt.args, parsed.args)
+			assert.Equal(t, tt.expanders, parsed.expanders)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	_, ok := matchPattern("@number@.between(1, 2)", "@number@")
+	assert.True(t, ok)
+
+	_, ok = matchPattern("@enum(1, 2)@", "@enum@")
+	assert.True(t, ok)
+
+	_, ok = matchPattern("$num.between(1, 2)", "$num")
+	assert.True(t, ok, "expected to support custom base pattern")
+
+	_, ok = matchPattern("@numbers@", "@number@")
+	assert.False(t, ok)
+
+	_, ok = matchPattern(1, "@number@")
+	assert.False(t, ok)
+}
+
+var parseArgsTests = []struct {
+	raw  string
+	args []patternArg
+	ok   bool
+}{
+	{"", nil, true},
+	{"  ", nil, true},
+	{`1, "a", true, null`, []patternArg{"1", `"a"`, "true", "null"}, true},
+	{`"a,b", ["x", "y"], {"k": "v,w"}`, []patternArg{`"a,b"`, `["x", "y"]`, `{"k": "v,w"}`}, true},
+	{`"unterminated`, nil, false},
+	{`desc`, nil, false},
+	{`1,`, nil, false},
+}
+
+func TestParseArgs(t *testing.T) {
+	for _, tt := range parseArgsTests {
+		args, err := parseArgs(tt.raw)
+
+		if tt.ok {
+			assert.Nil(t, err, tt.raw)
+			assert.Equal(t, tt.args, args, tt.raw)
+		} else {
+			assert.Error(t, err, tt.raw)
+		}
+	}
+}
+
+func TestPatternArgString(t *testing.T) {
+	assert.Equal(t, "a\"b", patternArg(`"a\"b"`).String())
+	assert.Equal(t, "12.5", patternArg(`12.5`).String())
+	assert.Equal(t, []string{"DE", "1"}, argsToStrings([]patternArg{`"DE"`, "1"}))
+}
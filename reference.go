@@ -0,0 +1,209 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errReferencesNotSupported = errors.New("references are not supported when matching a stream")
+
+// A reference points to a value of actual JSON.
+//
+// "$" refers to the root of the document and "$$" to the array or object containing
+// the matched value, so "$$..price", or shorter "$$.price", refers to a sibling
+// of the matched value. Every "^" after "$$" goes one level up, so "$$^..id" refers
+// to a sibling of the object containing the matched value.
+// Keys and indexes are selected with ".key", "[\"key\"]" and "[0]".
+type reference struct {
+	src      string
+	relative bool
+	// up is a number of levels above the containing value of a relative reference
+	up   int
+	path []interface{}
+}
+
+func (r *reference) String() string {
+	return r.src
+}
+
+// parseReference parses a reference starting at index start of s.
+// It returns the reference and index of the first byte after it.
+func parseReference(s string, start int) (*reference, int, error) {
+	if start >= len(s) || s[start] != '$' {
+		return nil, start, fmt.Errorf("expected reference at %d", start)
+	}
+	r := &reference{}
+	i := start + 1
+	if i < len(s) && s[i] == '$' {
+		r.relative = true
+		i++
+		for i < len(s) && s[i] == '^' {
+			r.up++
+			i++
+		}
+		// a sibling may be selected with "..key" as well as ".key"
+		if strings.HasPrefix(s[i:], "..") {
+			i++
+		}
+	}
+	for i < len(s) {
+		switch s[i] {
+		case '.':
+			j := i + 1
+			for j < len(s) && (isIdentByte(s[j]) || s[j] == '-') {
+				j++
+			}
+			if j == i+1 {
+				return nil, i, fmt.Errorf("expected key after '.' at %d", i)
+			}
+			r.path = append(r.path, s[i+1:j])
+			i = j
+		case '[':
+			end := closingBracket(s, i)
+			if end < 0 {
+				return nil, i, fmt.Errorf("unclosed '[' at %d", i)
+			}
+			key, err := parseSelector(s[i+1 : end])
+			if err != nil {
+				return nil, i, err
+			}
+			r.path = append(r.path, key)
+			i = end + 1
+		default:
+			r.src = s[start:i]
+			return r, i, nil
+		}
+	}
+	r.src = s[start:i]
+	return r, i, nil
+}
+
+// parseSelector parses a key or an index given in brackets.
+func parseSelector(s string) (interface{}, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	var key string
+	if err := json.Unmarshal([]byte(s), &key); err != nil {
+		return nil, fmt.Errorf("invalid selector [%s]", s)
+	}
+	return key, nil
+}
+
+// resolve returns a value the reference points to within actual JSON being matched.
+func (r *reference) resolve(s *matchState) (interface{}, error) {
+	if s.streaming {
+		return nil, errReferencesNotSupported
+	}
+	v := s.root
+	if r.relative {
+		if len(s.parents) <= r.up {
+			return nil, fmt.Errorf(`reference "%s" has no containing value`, r.src)
+		}
+		v = s.parents[len(s.parents)-1-r.up]
+	}
+	for _, key := range r.path {
+		var ok bool
+		switch k := key.(type) {
+		case string:
+			var m map[string]interface{}
+			if m, ok = v.(map[string]interface{}); ok {
+				v, ok = m[k]
+			}
+		case int:
+			var a []interface{}
+			if a, ok = v.([]interface{}); ok && k < len(a) {
+				v = a[k]
+			} else {
+				ok = false
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf(`reference "%s" not found`, r.src)
+		}
+	}
+	return v, nil
+}
+
+// A referencePattern is a value pattern having references in arguments,
+// e.g. "@number@.equals($.total)". References are replaced with JSON of values
+// they point to before the pattern is resolved by a value matcher.
+type referencePattern struct {
+	src string
+	// parts hold text of the pattern around references
+	parts []string
+	refs  []*reference
+}
+
+// parseReferencePattern parses s if it is a value pattern having references in arguments.
+// It returns nil if s is not such pattern or its base pattern is unknown,
+// so strings like "@price@($5)" are still compared as strings.
+func (c *config) parseReferencePattern(s string) (*referencePattern, error) {
+	if _, ok := parsePattern(s); !ok {
+		return nil, nil
+	}
+	p := &referencePattern{src: s}
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			if i = closingQuote(s, i); i < 0 {
+				return nil, nil
+			}
+		case '$':
+			ref, end, err := parseReference(s, i)
+			if err != nil {
+				return nil, err
+			}
+			p.parts = append(p.parts, s[start:i])
+			p.refs = append(p.refs, ref)
+			start = end
+			i = end - 1
+		}
+	}
+	if len(p.refs) == 0 {
+		return nil, nil
+	}
+	p.parts = append(p.parts, s[start:])
+	// references in arguments of the base pattern are replaced with null to check it
+	placeholder := strings.Join(p.parts, "null")
+	parsed, ok := parsePattern(placeholder)
+	if !ok || c.resolvePattern(parsed.head(placeholder, 0)) == nil {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// pattern returns the pattern with references replaced by JSON of values they point to.
+func (p *referencePattern) pattern(s *matchState) (string, error) {
+	var b strings.Builder
+	for i, ref := range p.refs {
+		v, err := ref.resolve(s)
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(p.parts[i])
+		b.Write(data)
+	}
+	b.WriteString(p.parts[len(p.parts)-1])
+	return b.String(), nil
+}
+
+func (p *referencePattern) match(s *matchState, v interface{}) error {
+	pattern, err := p.pattern(s)
+	if err != nil {
+		return err
+	}
+	vp := s.p.resolvePattern(pattern)
+	if vp == nil {
+		return fmt.Errorf(`invalid pattern "%s" for referenced values`, pattern)
+	}
+	return vp.match(s.p.registry, v)
+}
@@ -0,0 +1,99 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		desc     string
+		s        string
+		relative bool
+		up       int
+		path     []interface{}
+		end      int
+	}{
+		{"Should parse root", "$", false, 0, nil, 1},
+		{"Should parse parent", "$$", true, 0, nil, 2},
+		{"Should parse keys", "$.a.b_c-d", false, 0, []interface{}{"a", "b_c-d"}, 9},
+		{"Should parse indexes and quoted keys", `$$[0]["a.b"] == 1`, true, 0, []interface{}{0, "a.b"}, 12},
+		{"Should parse sibling", "$$..id", true, 0, []interface{}{"id"}, 6},
+		{"Should parse levels up", "$$^^.id", true, 2, []interface{}{"id"}, 7},
+		{"Should parse sibling of levels up", "$$^..id", true, 1, []interface{}{"id"}, 7},
+	}
+	for _, tt := range tests {
+		t.Logf(tt.desc)
+		r, end, err := parseReference(tt.s, 0)
+
+		if assert.Nil(t, err) {
+			assert.Equal(t, tt.relative, r.relative)
+			assert.Equal(t, tt.up, r.up)
+			assert.Equal(t, tt.path, r.path)
+			assert.Equal(t, tt.end, end)
+			assert.Equal(t, tt.s[:end], r.String())
+		}
+	}
+}
+
+func TestParseReferenceInvalid(t *testing.T) {
+	for _, s := range []string{"a", "$.", "$[0", "$[a]", "$[-1]", "$$..", "$$...id"} {
+		t.Logf(s)
+		_, _, err := parseReference(s, 0)
+
+		assert.NotNil(t, err)
+	}
+}
+
+var referencePatternTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{"Should resolve references from root", `{"total_count": "@number@", "count": "@number@.equals($.total_count)"}`, `{"total_count": 2, "count": 2}`, true, ""},
+	{"Should report mismatch of referenced value", `{"total_count": "@number@", "count": "@number@.equals($.total_count)"}`, `{"total_count": 2, "count": 3}`, false, "expected number equal to 2 at path: count"},
+	{"Should resolve siblings", `{"name": "@string@", "login": "@string@.equals($$.name)"}`, `{"name": "joe", "login": "joe"}`, true, ""},
+	{"Should resolve siblings with double dot", `{"name": "@string@", "login": "@string@.equals($$..name)"}`, `{"name": "joe", "login": "joe"}`, true, ""},
+	{"Should resolve levels up", `{"id": "@number@", "child": {"parent_id": "@number@.equals($$^..id)"}}`, `{"id": 5, "child": {"parent_id": 5}}`, true, ""},
+	{"Should compare array length with referenced value", `{"total_count": "@number@", "items": "@array@.length($.total_count)"}`, `{"total_count": 2, "items": [1, 2]}`, true, ""},
+	{"Should report array length mismatch with referenced value", `{"total_count": "@number@", "items": "@array@.length($.total_count)"}`, `{"total_count": 3, "items": [1, 2]}`, false, "expected array of length 3, got 2 at path: items"},
+	{"Should report missing reference", `{"count": "@number@.equals($.total)"}`, `{"count": 1}`, false, `reference "$.total" not found at path: count`},
+	{"Should report referenced value of a wrong type", `{"name": "@string@", "login": "@string@.equals($$.id)", "id": 1}`, `{"name": "joe", "login": "joe", "id": 1}`, false, `invalid pattern "@string@.equals(1)" for referenced values at path: login`},
+	{"Should compare strings with unknown base pattern", `{"price": "@price@($5)"}`, `{"price": "@price@($5)"}`, true, ""},
+}
+
+func TestReferencePattern(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range referencePatternTests {
+		t.Logf(tt.desc)
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestReferencePatternWithRegistry(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithRegistry(newTestRegistry()))
+
+	ok, err := m.Match(
+		`{"country": "@string@", "iban": "@iban@($$.country)"}`,
+		`{"country": "PL", "iban": "DE89370400440532013000"}`,
+	)
+	assert.EqualError(t, err, "expected IBAN from PL at path: iban")
+	assert.False(t, ok)
+}
+
+func TestReferencePatternInvalid(t *testing.T) {
+	_, err := NewDefaultJSONMatcher().Compile(`{"a": "@number@.equals($.)"}`)
+
+	assert.EqualError(t, err, "invalid JSON pattern: expected key after '.' at 17 at path: a")
+}
@@ -0,0 +1,143 @@
+package gomatch
+
+import (
+	"fmt"
+	"sync"
+)
+
+// A PatternFunc checks value v against a named pattern with given arguments.
+// It returns an error describing a mismatch.
+type PatternFunc func(v interface{}, args []string) error
+
+// A Registry is a ValueMatcher handling named patterns implemented by functions.
+//
+// A function registered as "iban" handles "@iban@" pattern. Arguments are parsed
+// by the library and passed as strings, so "@iban(\"DE\")@" and "@iban@(\"DE\")"
+// call the function with []string{"DE"}.
+//
+// Registered functions may also be used as expanders, e.g. "@iban@.bank(\"COBADEFF\")".
+// When Registry is set with WithRegistry option, its functions may also expand patterns
+// handled by other value matchers, e.g. "@string@.iban()".
+type Registry struct {
+	mu    sync.RWMutex
+	funcs map[string]PatternFunc
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{funcs: make(map[string]PatternFunc)}
+}
+
+// Register registers fn as a pattern with given name.
+// The name may contain only letters, digits and underscores, otherwise Register panics.
+func (r *Registry) Register(name string, fn PatternFunc) {
+	if name == "" {
+		panic("gomatch: empty pattern name")
+	}
+	for i := 0; i < len(name); i++ {
+		if !isIdentByte(name[i]) {
+			panic(fmt.Sprintf("gomatch: invalid pattern name %q", name))
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.funcs[name] = fn
+}
+
+func (r *Registry) lookup(name string) (PatternFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.funcs[name]
+	return fn, ok
+}
+
+// CanMatch returns true if p is a registered pattern and all its expanders are registered.
+func (r *Registry) CanMatch(p interface{}) bool {
+	s, ok := p.(string)
+	if !ok {
+		return false
+	}
+	parsed, ok := parsePattern(s)
+	if !ok {
+		return false
+	}
+	if _, ok := r.lookup(parsed.name); !ok {
+		return false
+	}
+	if _, err := parseArgs(parsed.args); err != nil {
+		return false
+	}
+	return r.canExpand(parsed.expanders)
+}
+
+// canExpand returns true if all expanders are registered and have valid arguments.
+func (r *Registry) canExpand(expanders []patternCall) bool {
+	for _, e := range expanders {
+		if _, ok := r.lookup(e.name); !ok {
+			return false
+		}
+		if _, err := parseArgs(e.args); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Match calls a function registered for pattern p and functions of all its expanders.
+func (r *Registry) Match(p, v interface{}) (bool, error) {
+	s, _ := p.(string)
+	parsed, ok := parsePattern(s)
+	if !ok {
+		return false, errMatcherNotFound
+	}
+	err := r.call(patternCall{parsed.name, parsed.args}, v)
+	if err == nil {
+		err = r.expand(parsed.expanders, v)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Registry) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	if !r.CanMatch(p) {
+		return nil, false
+	}
+	parsed, _ := parsePattern(p.(string))
+	calls := append([]patternCall{{parsed.name, parsed.args}}, parsed.expanders...)
+	return func(v interface{}) error {
+		return r.expand(calls, v)
+	}, true
+}
+
+// expand calls functions registered for given expanders.
+func (r *Registry) expand(expanders []patternCall, v interface{}) error {
+	for _, e := range expanders {
+		if err := r.call(e, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) call(c patternCall, v interface{}) error {
+	fn, ok := r.lookup(c.name)
+	if !ok {
+		return errMatcherNotFound
+	}
+	args, err := parseArgs(c.args)
+	if err != nil {
+		return err
+	}
+	return fn(v, argsToStrings(args))
+}
+
+// WithRegistry makes JSONMatcher use patterns registered in r.
+// Registered functions are also used as expanders of patterns handled by other value matchers,
+// e.g. "@string@.iban()".
+func WithRegistry(r *Registry) Option {
+	return func(c *config) {
+		c.registry = r
+	}
+}
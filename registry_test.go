@@ -0,0 +1,103 @@
+package gomatch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("iban", func(v interface{}, args []string) error {
+		s, ok := v.(string)
+		if !ok || len(s) < 15 {
+			return errors.New("expected IBAN")
+		}
+		if len(args) > 0 && !strings.HasPrefix(s, args[0]) {
+			return fmt.Errorf("expected IBAN from %s", args[0])
+		}
+		return nil
+	})
+	r.Register("upper", func(v interface{}, args []string) error {
+		if s, ok := v.(string); ok && s == strings.ToUpper(s) {
+			return nil
+		}
+		return errors.New("expected upper case string")
+	})
+	return r
+}
+
+var registryTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should call registered function", "@iban@", "DE89370400440532013000", true, ""},
+	{"Should return error of registered function", "@iban@", "DE89", false, "expected IBAN"},
+	{"Should pass arguments given inside delimiters", `@iban("DE")@`, "PL61109010140000071219812874", false, "expected IBAN from DE"},
+	{"Should pass arguments given after delimiters", `@iban@("PL")`, "PL61109010140000071219812874", true, ""},
+	{"Should call registered expanders", `@iban@.upper()`, "de89370400440532013000", false, "expected upper case string"},
+}
+
+func TestRegistry(t *testing.T) {
+	r := newTestRegistry()
+	for _, tt := range registryTests {
+		t.Logf(tt.desc)
+		assert.True(t, r.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := r.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestRegistryCanMatch(t *testing.T) {
+	r := newTestRegistry()
+
+	assert.False(t, r.CanMatch("@bic@"), "not expected to support unregistered pattern")
+	assert.False(t, r.CanMatch("@iban@.bic()"), "not expected to support unregistered expander")
+	assert.False(t, r.CanMatch("@iban(DE)@"), "not expected to support invalid arguments")
+	assert.False(t, r.CanMatch(1))
+}
+
+func TestRegistryRegisterInvalidName(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRegistry().Register("my-pattern", func(v interface{}, args []string) error { return nil })
+	})
+}
+
+func TestJSONMatcherWithRegistry(t *testing.T) {
+	m := NewDefaultJSONMatcher(WithRegistry(newTestRegistry()))
+
+	ok, err := m.Match(
+		`{"iban": "@iban@(\"DE\")", "currency": "@string@.upper()", "@...@": ""}`,
+		`{"iban": "DE89370400440532013000", "currency": "EUR", "amount": 1}`,
+	)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = m.Match(`{"currency": "@string@.upper()"}`, `{"currency": "eur"}`)
+	assert.EqualError(t, err, "expected upper case string at path: currency")
+	assert.False(t, ok)
+
+	ok, err = m.Match(`{"currency": "@string@.upper()"}`, `{"currency": 1}`)
+	assert.EqualError(t, err, "expected string at path: currency")
+	assert.False(t, ok)
+
+	issues, err := m.Lint(`{"currency": "@string@.upper()", "iban": "@string@.bic()"}`)
+	assert.Nil(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, "iban", issues[0].Path)
+	}
+}
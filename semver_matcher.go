@@ -0,0 +1,349 @@
+package gomatch
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var errNotSemver = errors.New("expected semantic version")
+
+// A SemverMatcher matches semantic versions as defined by SemVer 2.0.0,
+// e.g. "1.4.2", "1.4.2-rc.1" or "1.4.2+build.5".
+//
+// Supported expanders:
+//
+//  @semver@.satisfies(">=1.2.0 <2.0.0")
+//  @semver@.satisfies("^1.2 || ~2.0.1")
+//  @semver@.stable()    // without a pre-release part
+//
+// A constraint is a list of ranges separated by "||", a range is a list of comparators
+// separated by spaces, all of which have to be satisfied. Comparators use operators
+// "=", ">", ">=", "<", "<=", "~" (patch updates) and "^" (compatible updates), versions
+// may be partial, e.g. "1.2" stands for any 1.2.x version. Versions are compared with
+// SemVer precedence, so pre-releases are ordered before their release.
+type SemverMatcher struct {
+	pattern string
+}
+
+var semverExpanders = expanderSet{
+	"satisfies": semverSatisfies,
+	"stable":    semverStable,
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *SemverMatcher) CanMatch(p interface{}) bool {
+	_, ok := semverExpanders.build(p, m.pattern)
+	return ok
+}
+
+// Match performs value matching against given pattern.
+func (m *SemverMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *SemverMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return semverExpanders.compile(p, m.pattern, validateSemver)
+}
+
+func (m *SemverMatcher) patternExpanders() expanderSet {
+	return semverExpanders
+}
+
+func validateSemver(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return errNotSemver
+	}
+	if _, ok := parseSemver(s); !ok {
+		return errNotSemver
+	}
+	return nil
+}
+
+// NewSemverMatcher creates SemverMatcher.
+func NewSemverMatcher(pattern string) *SemverMatcher {
+	return &SemverMatcher{pattern}
+}
+
+func semverSatisfies(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	if !args[0].isString() {
+		return nil, errNotString
+	}
+	c, err := parseSemverConstraint(args[0].String())
+	if err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		ver, _ := parseSemver(v.(string))
+		if !c.check(ver) {
+			return fmt.Errorf("expected version satisfying %s", string(args[0]))
+		}
+		return nil
+	}, nil
+}
+
+func semverStable(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		ver, _ := parseSemver(v.(string))
+		if len(ver.pre) > 0 {
+			return errors.New("expected stable version")
+		}
+		return nil
+	}, nil
+}
+
+// A semver is a parsed semantic version without build metadata.
+type semver struct {
+	nums [3]*big.Int
+	pre  []string
+}
+
+// parseSemver parses a full semantic version.
+func parseSemver(s string) (semver, bool) {
+	var v semver
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		if !validSemverIdents(s[i+1:], false) {
+			return v, false
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		if !validSemverIdents(s[i+1:], true) {
+			return v, false
+		}
+		v.pre = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	nums, ok := parseSemverNums(s)
+	if !ok || len(nums) != 3 {
+		return v, false
+	}
+	copy(v.nums[:], nums)
+	return v, true
+}
+
+// parseSemverNums parses dot separated numbers of a version core, e.g. "1.2".
+func parseSemverNums(s string) ([]*big.Int, bool) {
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return nil, false
+	}
+	nums := make([]*big.Int, len(parts))
+	for i, p := range parts {
+		if !isNumericIdent(p) {
+			return nil, false
+		}
+		nums[i], _ = new(big.Int).SetString(p, 10)
+	}
+	return nums, true
+}
+
+// validSemverIdents checks dot separated pre-release or build identifiers.
+// Numeric pre-release identifiers must not have leading zeros.
+func validSemverIdents(s string, pre bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			if !isIdentByte(id[i]) && id[i] != '-' || id[i] == '_' {
+				return false
+			}
+		}
+		if pre && isDigits(id) && !isNumericIdent(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericIdent checks if s is a number without leading zeros.
+func isNumericIdent(s string) bool {
+	return s != "" && isDigits(s) && (s == "0" || s[0] != '0')
+}
+
+// compare compares versions by SemVer precedence.
+func (v semver) compare(o semver) int {
+	for i := range v.nums {
+		if c := v.nums[i].Cmp(o.nums[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePrerelease(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return len(v.pre) - len(o.pre)
+}
+
+// comparePrerelease compares pre-release identifiers, numeric ones have lower precedence.
+func comparePrerelease(a, b string) int {
+	numA, numB := isDigits(a), isDigits(b)
+	switch {
+	case numA && numB:
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	case numA:
+		return -1
+	case numB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// A semverComparator checks a version with a single operator, one of "=", ">", ">=", "<" and "<=".
+type semverComparator struct {
+	op string
+	v  semver
+}
+
+func (c semverComparator) check(v semver) bool {
+	r := v.compare(c.v)
+	switch c.op {
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	}
+	return r == 0
+}
+
+// A semverConstraint holds alternative ranges of comparators.
+type semverConstraint [][]semverComparator
+
+func (c semverConstraint) check(v semver) bool {
+	for _, r := range c {
+		ok := true
+		for _, cmp := range r {
+			ok = ok && cmp.check(v)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+var semverOperators = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+func parseSemverConstraint(s string) (semverConstraint, error) {
+	var c semverConstraint
+	for _, r := range strings.Split(s, "||") {
+		fields := strings.Fields(r)
+		if len(fields) == 0 {
+			return nil, errInvalidArgs
+		}
+		var comparators []semverComparator
+		for _, f := range fields {
+			cs, ok := parseSemverComparator(f)
+			if !ok {
+				return nil, fmt.Errorf("invalid version constraint %q", f)
+			}
+			comparators = append(comparators, cs...)
+		}
+		c = append(c, comparators)
+	}
+	return c, nil
+}
+
+// parseSemverComparator converts a comparator, e.g. "^1.2", to basic comparators.
+func parseSemverComparator(s string) ([]semverComparator, bool) {
+	op := ""
+	for _, o := range semverOperators {
+		if strings.HasPrefix(s, o) {
+			op = o
+			break
+		}
+	}
+	s = s[len(op):]
+	if v, ok := parseSemver(s); ok {
+		switch op {
+		case "~":
+			return semverRange(v, bumpSemver(v.nums[:2])), true
+		case "^":
+			return semverRange(v, bumpSemver(v.nums[:caretLen(v.nums[:])])), true
+		case "":
+			op = "="
+		}
+		return []semverComparator{{op, v}}, true
+	}
+	// partial version, e.g. "1.2" standing for any 1.2.x version
+	nums, ok := parseSemverNums(s)
+	if !ok || len(nums) == 3 {
+		return nil, false
+	}
+	lower := semver{}
+	for i := range lower.nums {
+		lower.nums[i] = new(big.Int)
+		if i < len(nums) {
+			lower.nums[i].Set(nums[i])
+		}
+	}
+	upper := bumpSemver(nums)
+	switch op {
+	case ">":
+		return []semverComparator{{">=", upper}}, true
+	case ">=":
+		return []semverComparator{{">=", lower}}, true
+	case "<":
+		return []semverComparator{{"<", lower}}, true
+	case "<=":
+		return []semverComparator{{"<", upper}}, true
+	case "^":
+		return semverRange(lower, bumpSemver(nums[:caretLen(nums)])), true
+	}
+	return semverRange(lower, upper), true
+}
+
+// caretLen returns number of leading version parts kept by "^" operator,
+// which allows changes not modifying the leftmost non-zero part.
+func caretLen(nums []*big.Int) int {
+	for i, n := range nums {
+		if n.Sign() != 0 {
+			return i + 1
+		}
+	}
+	return len(nums)
+}
+
+func semverRange(lower, upper semver) []semverComparator {
+	return []semverComparator{{">=", lower}, {"<", upper}}
+}
+
+// bumpSemver returns the lowest version greater than all versions starting with nums,
+// e.g. 1.3.0 for 1.2.
+func bumpSemver(nums []*big.Int) semver {
+	var v semver
+	for i := range v.nums {
+		v.nums[i] = new(big.Int)
+		if i < len(nums) {
+			v.nums[i].Set(nums[i])
+		}
+	}
+	last := len(nums) - 1
+	v.nums[last].Add(v.nums[last], big.NewInt(1))
+	return v
+}
@@ -0,0 +1,73 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var semverMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match version", "@semver@", "1.4.2", true, ""},
+	{"Should match version with pre-release and build", "@semver@", "1.4.2-rc.1+build.5", true, ""},
+	{"Should match version with hyphen in pre-release", "@semver@", "1.0.0-x-y.0", true, ""},
+	{"Should not match partial version", "@semver@", "1.4", false, "expected semantic version"},
+	{"Should not match v prefix", "@semver@", "v1.4.2", false, "expected semantic version"},
+	{"Should not match leading zero", "@semver@", "1.04.2", false, "expected semantic version"},
+	{"Should not match leading zero in numeric pre-release", "@semver@", "1.4.2-rc.01", false, "expected semantic version"},
+	{"Should not match empty pre-release identifier", "@semver@", "1.4.2-rc..1", false, "expected semantic version"},
+	{"Should not match number", "@semver@", 1., false, "expected semantic version"},
+	{"Should match version in range", `@semver@.satisfies(">=1.2.0 <2.0.0")`, "1.4.2", true, ""},
+	{"Should not match version out of range", `@semver@.satisfies(">=1.2.0 <2.0.0")`, "2.0.0", false, `expected version satisfying ">=1.2.0 <2.0.0"`},
+	{"Should order pre-release before release", `@semver@.satisfies("<1.0.0")`, "1.0.0-rc.1", true, ""},
+	{"Should order numeric pre-release identifiers numerically", `@semver@.satisfies(">1.0.0-rc.2")`, "1.0.0-rc.10", true, ""},
+	{"Should order numeric pre-release identifiers first", `@semver@.satisfies("<1.0.0-alpha")`, "1.0.0-1", true, ""},
+	{"Should order shorter pre-release first", `@semver@.satisfies(">1.0.0-alpha")`, "1.0.0-alpha.1", true, ""},
+	{"Should ignore build metadata", `@semver@.satisfies("=1.0.0")`, "1.0.0+build.1", true, ""},
+	{"Should match alternative ranges", `@semver@.satisfies("<1.0.0 || >=3.0.0")`, "3.1.0", true, ""},
+	{"Should match caret range", `@semver@.satisfies("^1.2.3")`, "1.9.0", true, ""},
+	{"Should not match caret range", `@semver@.satisfies("^1.2.3")`, "2.0.0", false, `expected version satisfying "^1.2.3"`},
+	{"Should match caret range of zero major", `@semver@.satisfies("^0.2.3")`, "0.2.9", true, ""},
+	{"Should not match caret range of zero major", `@semver@.satisfies("^0.2.3")`, "0.3.0", false, `expected version satisfying "^0.2.3"`},
+	{"Should match tilde range", `@semver@.satisfies("~1.2.3")`, "1.2.9", true, ""},
+	{"Should not match tilde range", `@semver@.satisfies("~1.2.3")`, "1.3.0", false, `expected version satisfying "~1.2.3"`},
+	{"Should match partial version", `@semver@.satisfies("1.2")`, "1.2.7", true, ""},
+	{"Should not match partial version", `@semver@.satisfies("1.2")`, "1.3.0", false, `expected version satisfying "1.2"`},
+	{"Should match greater than partial version", `@semver@.satisfies(">1")`, "2.0.0", true, ""},
+	{"Should not match greater than partial version", `@semver@.satisfies(">1")`, "1.9.9", false, `expected version satisfying ">1"`},
+	{"Should match at most partial version", `@semver@.satisfies("<=1.2")`, "1.2.9", true, ""},
+	{"Should match big numbers", `@semver@.satisfies(">18446744073709551615.0.0")`, "18446744073709551616.0.0", true, ""},
+	{"Should match stable version", "@semver@.stable()", "1.0.0+build.1", true, ""},
+	{"Should not match pre-release", "@semver@.stable()", "1.0.0-rc.1", false, "expected stable version"},
+}
+
+func TestSemverMatcher(t *testing.T) {
+	m := NewSemverMatcher("@semver@")
+	for _, tt := range semverMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestSemverMatcherInvalidExpanders(t *testing.T) {
+	m := NewSemverMatcher("@semver@")
+	for _, p := range []string{`@semver@.satisfies("")`, `@semver@.satisfies("1.2 ||")`, `@semver@.satisfies(">=x")`,
+		`@semver@.satisfies("=>1.0.0")`, "@semver@.satisfies(1)", "@semver@.stable(1)", "@semver@.unknown()"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
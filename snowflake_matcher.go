@@ -0,0 +1,64 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var errNotSnowflake = errors.New("expected Snowflake ID")
+
+// TwitterEpoch is the epoch of Twitter Snowflake IDs, 2010-11-04T01:42:54.657Z.
+var TwitterEpoch = time.Unix(0, 1288834974657*int64(time.Millisecond))
+
+// A SnowflakeMatcher matches Snowflake IDs: positive 63-bit integers with a timestamp
+// in milliseconds since an epoch stored in the highest 41 bits.
+// IDs may be given as JSON numbers or strings of digits, e.g. "1212836574000050176".
+//
+// Supported expanders check the embedded timestamp:
+//
+//  @snowflake@.after("2020-01-01T00:00:00Z")
+//  @snowflake@.before("2030-01-01T00:00:00Z")
+//  @snowflake@.within("24h")
+type SnowflakeMatcher struct {
+	*timestampIDMatcher
+}
+
+// NewSnowflakeMatcher creates SnowflakeMatcher of IDs using TwitterEpoch.
+func NewSnowflakeMatcher(pattern string) *SnowflakeMatcher {
+	return NewSnowflakeMatcherWithEpoch(pattern, TwitterEpoch)
+}
+
+// NewSnowflakeMatcherWithEpoch creates SnowflakeMatcher of IDs using given epoch,
+// e.g. Discord IDs use 2015-01-01T00:00:00Z.
+func NewSnowflakeMatcherWithEpoch(pattern string, epoch time.Time) *SnowflakeMatcher {
+	return &SnowflakeMatcher{newTimestampIDMatcher(pattern, snowflakeTimestamp(epoch), func(m *timestampIDMatcher) ValueMatcher {
+		return &SnowflakeMatcher{m}
+	})}
+}
+
+// snowflakeTimestamp returns a decoder of timestamps of Snowflake IDs using given epoch.
+func snowflakeTimestamp(epoch time.Time) timestampDecoder {
+	return func(v interface{}) (time.Time, error) {
+		var s string
+		switch t := v.(type) {
+		case string:
+			s = t
+		case json.Number:
+			s = t.String()
+		case float64:
+			s = strconv.FormatFloat(t, 'f', -1, 64)
+		default:
+			return time.Time{}, errNotSnowflake
+		}
+		if !isDigits(s) || s == "" {
+			return time.Time{}, errNotSnowflake
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || id <= 0 {
+			return time.Time{}, errNotSnowflake
+		}
+		return epoch.Add(time.Duration(id>>22) * time.Millisecond), nil
+	}
+}
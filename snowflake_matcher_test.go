@@ -0,0 +1,58 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var snowflakeMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match string ID", "@snowflake@", "1212836574000050176", true, ""},
+	{"Should match exact number ID", "@snowflake@", json.Number("1212836574000050176"), true, ""},
+	{"Should match float number ID", "@snowflake@", 1212836574000050176., true, ""},
+	{"Should not match zero", "@snowflake@", "0", false, "expected Snowflake ID"},
+	{"Should not match negative number", "@snowflake@", json.Number("-1"), false, "expected Snowflake ID"},
+	{"Should not match fraction", "@snowflake@", 1.5, false, "expected Snowflake ID"},
+	{"Should not match overflowing ID", "@snowflake@", "9223372036854775808", false, "expected Snowflake ID"},
+	{"Should not match string with letters", "@snowflake@", "12a", false, "expected Snowflake ID"},
+	{"Should not match bool", "@snowflake@", true, false, "expected Snowflake ID"},
+	{"Should match timestamp in range", `@snowflake@.after("2020-01-01T00:00:00Z").before("2020-01-03T00:00:00Z")`, "1212836574000050176", true, ""},
+	{"Should not match timestamp out of range", `@snowflake@.after("2021-01-01T00:00:00Z")`, "1212836574000050176", false, "expected timestamp after 2021-01-01T00:00:00Z, got 2020-01-02T20:42:27.312Z"},
+	{"Should match timestamp close to now", `@snowflake@.within("1h")`, "1212836574000050176", true, ""},
+}
+
+func TestSnowflakeMatcher(t *testing.T) {
+	m := NewSnowflakeMatcher("@snowflake@")
+	m.SetClock(func() time.Time { return time.Date(2020, 1, 2, 21, 0, 0, 0, time.UTC) })
+	for _, tt := range snowflakeMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestSnowflakeMatcherWithEpoch(t *testing.T) {
+	m := NewSnowflakeMatcherWithEpoch("@snowflake@", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := `@snowflake@.after("2016-04-30T11:18:25Z").before("2016-04-30T11:18:26Z")`
+
+	ok, err := m.Match(p, "175928847299117063")
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
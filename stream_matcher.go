@@ -0,0 +1,264 @@
+package gomatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MatchStream works like Match but reads actual JSON from r token by token
+// instead of decoding the whole document into memory first.
+//
+// The pattern is walked alongside the actual token stream, so only values matched
+// by value patterns are decoded. Array elements are matched one by one and elements
+// covered by an unbounded pattern "@...@" are skipped without being decoded.
+// It makes it possible to match very large documents in bounded memory,
+// e.g. an export file consisting of a huge array, every element of which is checked
+// as it is read:
+//
+//  {
+//  	"version": 2,
+//  	"items": "@array@.every({\"id\": \"@number@\", \"@...@\": \"\"})"
+//  }
+//
+// Note that a value pattern matching a whole array or object, e.g. "@array@.unique()",
+// decodes that value into memory.
+//
+// Matching stops at the first mismatch, so the rest of the stream is not validated.
+// With WithUnorderedArrays option arrays of the pattern are decoded as a whole.
+// Expression patterns can't refer to other values of the stream, see errReferencesNotSupported.
+func (p *CompiledPattern) MatchStream(r io.Reader) (bool, error) {
+	dec := json.NewDecoder(r)
+	if p.useNumber() {
+		dec.UseNumber()
+	}
+	state := p.newMatchState(nil)
+	state.maxErrors = 1
+	state.streaming = true
+	s := &streamMatcher{s: state, dec: dec}
+	err := s.match(p.expected)
+	if err == nil {
+		if _, err = dec.Token(); err == io.EOF {
+			return true, nil
+		}
+		err = errInvalidJSON
+	}
+	if err == errMismatch {
+		return false, state.err()
+	}
+	if _, ok := err.(*json.SyntaxError); ok || err == io.ErrUnexpectedEOF || err == io.EOF {
+		return false, errInvalidJSON
+	}
+	return false, err
+}
+
+// MatchStream works like Match but reads actual JSON from r token by token.
+// See CompiledPattern.MatchStream for details.
+func (m *JSONMatcher) MatchStream(expectedJSON string, actualJSON io.Reader) (bool, error) {
+	p, err := m.Compile(expectedJSON)
+	if err != nil {
+		return false, err
+	}
+	return p.MatchStream(actualJSON)
+}
+
+// errMismatch stops stream matching when a mismatch was reported.
+var errMismatch = errors.New("mismatch")
+
+type streamMatcher struct {
+	s   *matchState
+	dec *json.Decoder
+}
+
+// fail reports a mismatch and returns errMismatch to stop matching.
+func (s *streamMatcher) fail(err error) error {
+	s.s.fail(err)
+	return errMismatch
+}
+
+func (s *streamMatcher) match(expected interface{}) error {
+	if len(s.s.p.ignored) > 0 && s.s.ignored() {
+		return s.skipValue()
+	}
+	if e, ok := expected.(*everyPattern); ok && e.streamed() {
+		return s.matchEvery(e)
+	}
+	if s.s.p.canMatch(expected) || s.s.p.unorderedArrays && isArray(expected) {
+		var actual interface{}
+		if err := s.dec.Decode(&actual); err != nil {
+			return err
+		}
+		return s.deepMatch(expected, actual)
+	}
+	t, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+	switch e := expected.(type) {
+	case *compiledArray:
+		if t != json.Delim('[') {
+			return s.fail(errTypesNotEqual)
+		}
+		return s.matchArray(e.elements)
+
+	case *compiledObject:
+		if t != json.Delim('{') {
+			return s.fail(errTypesNotEqual)
+		}
+		return s.matchMap(e)
+
+	default:
+		if _, ok := t.(json.Delim); ok {
+			return s.fail(errTypesNotEqual)
+		}
+		return s.deepMatch(expected, t)
+	}
+}
+
+// deepMatch matches a value decoded as a whole.
+func (s *streamMatcher) deepMatch(expected, actual interface{}) error {
+	s.s.deepMatch(expected, actual)
+	if len(s.s.errs) > 0 {
+		return errMismatch
+	}
+	return nil
+}
+
+func (s *streamMatcher) matchArray(expected []interface{}) error {
+	for i, v := range expected {
+		if isUnbounded(v) {
+			for s.dec.More() {
+				if err := s.skipValue(); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if !s.dec.More() {
+			return s.fail(errArraysLenNotEqual)
+		}
+		s.s.push(i)
+		if err := s.match(v); err != nil {
+			return err
+		}
+		s.s.pop()
+	}
+	if s.dec.More() {
+		return s.fail(errArraysLenNotEqual)
+	}
+	_, err := s.dec.Token()
+	return err
+}
+
+// matchEvery matches elements of an array with "@array@.every(...)" pattern one by one.
+func (s *streamMatcher) matchEvery(e *everyPattern) error {
+	t, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+	if t != json.Delim('[') {
+		return s.fail(errNotArray)
+	}
+	for i := 0; s.dec.More(); i++ {
+		s.s.push(i)
+		if err := s.match(e.expected); err != nil {
+			return err
+		}
+		s.s.pop()
+	}
+	_, err = s.dec.Token()
+	return err
+}
+
+func (s *streamMatcher) matchMap(compiled *compiledObject) error {
+	expected := compiled.fields
+	unbounded := false
+	for k := range expected {
+		if isUnbounded(k) {
+			unbounded = true
+		}
+	}
+	var order *keyOrderChecker
+	if compiled.keys != nil {
+		order = newKeyOrderChecker(compiled.keys)
+	}
+	seen := make(map[string]bool)
+	// all keys are tracked only to detect duplicates of keys not in the pattern
+	all := make(map[string]bool)
+	for s.dec.More() {
+		t, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		k := t.(string)
+		if s.s.p.detectDuplicateKeys {
+			if all[k] {
+				s.s.push(k)
+				return s.fail(errDuplicateKey)
+			}
+			all[k] = true
+		}
+		if order != nil {
+			if err := order.next(k); err != nil {
+				return s.fail(err)
+			}
+		}
+		v, ok := expected[k]
+		if !ok || isUnbounded(k) {
+			if !unbounded && !s.s.ignoredKey(k) {
+				s.s.push(k)
+				// a null is read as a single token, any other value is a mismatch
+				if t, err := s.dec.Token(); err != nil || !s.s.p.allowsUnexpected(t) {
+					return s.fail(errUnexpectedKey)
+				}
+				s.s.pop()
+				continue
+			}
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		seen[k] = true
+		s.s.push(k)
+		if err := s.match(v); err != nil {
+			return err
+		}
+		s.s.pop()
+	}
+	if _, err := s.dec.Token(); err != nil {
+		return err
+	}
+	for _, k := range sortedKeys(expected) {
+		if !seen[k] && !isUnbounded(k) && !s.s.p.allowsMissing(expected[k]) && !s.s.ignoredKey(k) {
+			return s.fail(fmt.Errorf(`expected key "%s"`, k))
+		}
+	}
+	return nil
+}
+
+// skipValue reads next value token by token without keeping it in memory.
+func (s *streamMatcher) skipValue() error {
+	depth := 0
+	for {
+		t, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func isArray(v interface{}) bool {
+	_, ok := v.(*compiledArray)
+	return ok
+}
@@ -0,0 +1,142 @@
+package gomatch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchStream(t *testing.T) {
+	m := NewJSONMatcher(NewWildcardMatcher(patternWildcard))
+	for _, tt := range jsonMatcherTests {
+		ok, err := m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		t.Logf(tt.desc)
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+var matchStreamTests = []struct {
+	desc   string
+	p      string
+	v      string
+	ok     bool
+	errMsg string
+}{
+	{
+		"Should report path within value decoded by value pattern",
+		`{"items": [{"id": "@number@", "tags": "@array@"}]}`,
+		`{"items": [{"id": 1, "tags": {}}]}`,
+		false,
+		"expected array at path: items[0].tags",
+	},
+	{
+		"Should skip extra keys with nested values when unbounded pattern is used",
+		`{"id": "@number@", "@...@": ""}`,
+		`{"meta": {"a": [1, {"b": 2}]}, "id": 1, "list": [[], {}]}`,
+		true,
+		"",
+	},
+	{
+		"Should fail if expected key is missing",
+		`{"id": "@number@", "name": "@string@"}`,
+		`{"id": 1}`,
+		false,
+		`expected key "name"`,
+	},
+	{
+		"Should fail if array is expected but object given",
+		`{"items": [1]}`,
+		`{"items": {"0": 1}}`,
+		false,
+		"types are not equal at path: items",
+	},
+	{
+		"Should fail if scalar is expected but array given",
+		`{"id": 1}`,
+		`{"id": [1]}`,
+		false,
+		"types are not equal at path: id",
+	},
+	{
+		"Should fail if there is data after the document",
+		`{"id": 1}`,
+		`{"id": 1} {"id": 2}`,
+		false,
+		"invalid JSON",
+	},
+	{
+		"Should fail if document is truncated",
+		`["@...@"]`,
+		`[1, 2, 3`,
+		false,
+		"invalid JSON",
+	},
+}
+
+func TestMatchStreamCases(t *testing.T) {
+	m := NewDefaultJSONMatcher()
+	for _, tt := range matchStreamTests {
+		ok, err := m.MatchStream(tt.p, strings.NewReader(tt.v))
+
+		t.Logf(tt.desc)
+		if tt.ok {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestMatchStreamLargeArray(t *testing.T) {
+	p, err := NewDefaultJSONMatcher().Compile(`{"items": "@array@.every({\"id\": \"@number@\", \"name\": \"@string@\"})"}`)
+	assert.Nil(t, err)
+
+	for _, tt := range []struct {
+		bad    int
+		errMsg string
+	}{
+		{-1, ""},
+		{99998, "expected number at path: items[99998].id"},
+	} {
+		r, w := io.Pipe()
+		go func(bad int) {
+			io.WriteString(w, `{"items": [`)
+			for i := 0; i < 100000; i++ {
+				if i > 0 {
+					io.WriteString(w, ",")
+				}
+				if i == bad {
+					fmt.Fprintf(w, `{"id": "bad", "name": "item %d"}`, i)
+					continue
+				}
+				fmt.Fprintf(w, `{"id": %d, "name": "item %d"}`, i, i)
+			}
+			io.WriteString(w, `]}`)
+			w.Close()
+		}(tt.bad)
+
+		ok, err := p.MatchStream(r)
+		r.Close()
+
+		if tt.errMsg == "" {
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		} else {
+			assert.EqualError(t, err, tt.errMsg)
+			assert.False(t, ok)
+		}
+	}
+}
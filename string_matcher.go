@@ -1,29 +1,176 @@
 package gomatch
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
 
 var errNotString = errors.New("expected string")
 
 // A StringMatcher matches any string
+//
+// Supported expanders:
+//
+//  @string@.equals("text")
+//  @string@.notEmpty()
+//  @string@.minLength(3)
+//  @string@.maxLength(64)
+//  @string@.contains("text")
+//  @string@.startsWith("text")
+//  @string@.endsWith("text")
+//  @string@.oneOf("ACTIVE", "SUSPENDED", "DELETED")
+//  @string@.isLowercase()
+//  @string@.isUppercase()
+//
+// Lengths are counted in Unicode code points, so "zażółć" has length 6.
 type StringMatcher struct {
 	pattern string
 }
 
+var stringExpanders = expanderSet{
+	"equals":      stringEquals,
+	"notEmpty":    stringNotEmpty,
+	"minLength":   stringLength("at least", func(n, limit int) bool { return n >= limit }),
+	"maxLength":   stringLength("at most", func(n, limit int) bool { return n <= limit }),
+	"contains":    stringCheck("containing", strings.Contains),
+	"startsWith":  stringCheck("starting with", strings.HasPrefix),
+	"endsWith":    stringCheck("ending with", strings.HasSuffix),
+	"oneOf":       stringOneOf,
+	"isLowercase": stringCase("lower case", strings.ToLower),
+	"isUppercase": stringCase("upper case", strings.ToUpper),
+}
+
 // CanMatch returns true if pattern p can be handled.
 func (m *StringMatcher) CanMatch(p interface{}) bool {
-	return isPattern(p, m.pattern)
+	_, ok := stringExpanders.build(p, m.pattern)
+	return ok
 }
 
 // Match performs value matching against given pattern.
 func (m *StringMatcher) Match(p, v interface{}) (bool, error) {
-	_, ok := v.(string)
-	if ok {
-		return ok, nil
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *StringMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return stringExpanders.compile(p, m.pattern, validateString)
+}
+
+func (m *StringMatcher) patternExpanders() expanderSet {
+	return stringExpanders
+}
+
+func validateString(v interface{}) error {
+	if _, ok := v.(string); !ok {
+		return errNotString
 	}
-	return ok, errNotString
+	return nil
+}
+
+func stringEquals(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	if !args[0].isString() {
+		return nil, errNotString
+	}
+	expected := args[0].String()
+	return func(v interface{}) error {
+		if v.(string) != expected {
+			return fmt.Errorf("expected string equal to %s", string(args[0]))
+		}
+		return nil
+	}, nil
 }
 
 // NewStringMatcher creates StringMatcher.
 func NewStringMatcher(pattern string) *StringMatcher {
 	return &StringMatcher{pattern}
 }
+
+func stringNotEmpty(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		if v.(string) == "" {
+			return errors.New("expected not empty string")
+		}
+		return nil
+	}, nil
+}
+
+// stringLength checks length of a string against a limit with given comparison.
+func stringLength(desc string, cmp func(n, limit int) bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 1); err != nil {
+			return nil, err
+		}
+		limit, err := intArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(v interface{}) error {
+			if n := utf8.RuneCountInString(v.(string)); !cmp(n, limit) {
+				return fmt.Errorf("expected string of length %s %d, got %d", desc, limit, n)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// stringCheck checks a string against a string argument with given function.
+func stringCheck(desc string, fn func(s, arg string) bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 1); err != nil {
+			return nil, err
+		}
+		if !args[0].isString() {
+			return nil, errNotString
+		}
+		arg := args[0].String()
+		return func(v interface{}) error {
+			if !fn(v.(string), arg) {
+				return fmt.Errorf("expected string %s %s", desc, string(args[0]))
+			}
+			return nil
+		}, nil
+	}
+}
+
+func stringOneOf(args []patternArg) (func(v interface{}) error, error) {
+	if len(args) == 0 {
+		return nil, errInvalidArgs
+	}
+	allowed := make(map[string]bool, len(args))
+	strs := make([]string, len(args))
+	for i, a := range args {
+		if !a.isString() {
+			return nil, errNotString
+		}
+		allowed[a.String()] = true
+		strs[i] = string(a)
+	}
+	return func(v interface{}) error {
+		if !allowed[v.(string)] {
+			return fmt.Errorf("expected one of %s", strings.Join(strs, ", "))
+		}
+		return nil
+	}, nil
+}
+
+// stringCase checks if a string does not change when converted with fn.
+func stringCase(desc string, fn func(s string) string) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 0); err != nil {
+			return nil, err
+		}
+		return func(v interface{}) error {
+			if s := v.(string); fn(s) != s {
+				return fmt.Errorf("expected %s string", desc)
+			}
+			return nil
+		}, nil
+	}
+}
@@ -52,3 +52,56 @@ func TestStringMatcher(t *testing.T) {
 		}
 	}
 }
+
+var stringExpanderTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match equal string", `@string@.equals("abc")`, "abc", true, ""},
+	{"Should not match different string", `@string@.equals("abc")`, "ABC", false, `expected string equal to "abc"`},
+	{"Should check type before expanders", `@string@.equals("1")`, 1., false, "expected string"},
+	{"Should match not empty string", "@string@.notEmpty()", " ", true, ""},
+	{"Should not match empty string", "@string@.notEmpty()", "", false, "expected not empty string"},
+	{"Should count length in code points", "@string@.minLength(6).maxLength(6)", "zażółć", true, ""},
+	{"Should not match too short string", "@string@.minLength(3)", "ab", false, "expected string of length at least 3, got 2"},
+	{"Should not match too long string", "@string@.maxLength(2)", "żółw", false, "expected string of length at most 2, got 4"},
+	{"Should match string containing text", `@string@.contains("b").startsWith("a").endsWith("c")`, "abc", true, ""},
+	{"Should not match string not containing text", `@string@.contains("x")`, "abc", false, `expected string containing "x"`},
+	{"Should not match string not starting with text", `@string@.startsWith("b")`, "abc", false, `expected string starting with "b"`},
+	{"Should not match string not ending with text", `@string@.endsWith("b")`, "abc", false, `expected string ending with "b"`},
+	{"Should match one of strings", `@string@.oneOf("ACTIVE", "DELETED")`, "DELETED", true, ""},
+	{"Should not match other string", `@string@.oneOf("ACTIVE", "DELETED")`, "active", false, `expected one of "ACTIVE", "DELETED"`},
+	{"Should match lower case string", "@string@.isLowercase()", "ąbc-1", true, ""},
+	{"Should not match not lower case string", "@string@.isLowercase()", "aBc", false, "expected lower case string"},
+	{"Should match upper case string", "@string@.isUppercase()", "ĄBC-1", true, ""},
+	{"Should not match not upper case string", "@string@.isUppercase()", "ABć", false, "expected upper case string"},
+}
+
+func TestStringMatcherExpanders(t *testing.T) {
+	m := NewStringMatcher("@string@")
+	for _, tt := range stringExpanderTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestStringMatcherInvalidExpanders(t *testing.T) {
+	m := NewStringMatcher("@string@")
+	for _, p := range []string{"@string@.equals(1)", "@string@.equals()", "@string@.unknown()",
+		"@string@.minLength(-1)", "@string@.maxLength(1.5)", "@string@.contains(1)", "@string@.oneOf()", "@string@.isLowercase(1)"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
@@ -0,0 +1,57 @@
+package gomatch
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var errNotULID = errors.New("expected ULID")
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// An ULIDMatcher matches ULIDs, e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+// Lower case ULIDs are matched as well.
+//
+// Supported expanders check the embedded timestamp:
+//
+//  @ulid@.after("2020-01-01T00:00:00Z")
+//  @ulid@.before("2030-01-01T00:00:00Z")
+//  @ulid@.within("24h")
+type ULIDMatcher struct {
+	*timestampIDMatcher
+}
+
+// NewULIDMatcher creates ULIDMatcher.
+func NewULIDMatcher(pattern string) *ULIDMatcher {
+	return &ULIDMatcher{newTimestampIDMatcher(pattern, ulidTimestamp, func(m *timestampIDMatcher) ValueMatcher {
+		return &ULIDMatcher{m}
+	})}
+}
+
+// ulidTimestamp returns a timestamp encoded in the first 10 characters of ULID.
+func ulidTimestamp(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	// the first character encodes only 3 bits of 128-bit value
+	if !ok || len(s) != 26 || s[0] > '7' {
+		return time.Time{}, errNotULID
+	}
+	var ms int64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(crockfordAlphabet, upper(s[i]))
+		if d < 0 {
+			return time.Time{}, errNotULID
+		}
+		if i < 10 {
+			ms = ms<<5 | int64(d)
+		}
+	}
+	return time.Unix(0, 0).Add(time.Duration(ms) * time.Millisecond), nil
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,54 @@
+package gomatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ulidMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match ULID", "@ulid@", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true, ""},
+	{"Should match lower case ULID", "@ulid@", "01arz3ndektsv4rrffq69g5fav", true, ""},
+	{"Should not match number", "@ulid@", 1., false, "expected ULID"},
+	{"Should not match too short ULID", "@ulid@", "01ARZ3NDEKTSV4RRFFQ69G5FA", false, "expected ULID"},
+	{"Should not match invalid character", "@ulid@", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false, "expected ULID"},
+	{"Should not match overflowing ULID", "@ulid@", "81ARZ3NDEKTSV4RRFFQ69G5FAV", false, "expected ULID"},
+	{"Should match timestamp in range", `@ulid@.after("2016-01-01T00:00:00Z").before("2017-01-01T00:00:00Z")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", true, ""},
+	{"Should not match timestamp before", `@ulid@.after("2017-01-01T00:00:00Z")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", false, "expected timestamp after 2017-01-01T00:00:00Z, got 2016-07-30T23:54:10.259Z"},
+	{"Should not match timestamp after", `@ulid@.before("2016-01-01T00:00:00Z")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", false, "expected timestamp before 2016-01-01T00:00:00Z, got 2016-07-30T23:54:10.259Z"},
+	{"Should match timestamp close to now", `@ulid@.within("1h")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", true, ""},
+	{"Should not match timestamp far from now", `@ulid@.within("1m")`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", false, "expected timestamp within 1m0s from now, got 2016-07-30T23:54:10.259Z"},
+}
+
+func TestULIDMatcher(t *testing.T) {
+	m := NewULIDMatcher("@ulid@")
+	m.SetClock(func() time.Time { return time.Date(2016, 7, 31, 0, 0, 0, 0, time.UTC) })
+	for _, tt := range ulidMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestULIDMatcherInvalidExpanders(t *testing.T) {
+	m := NewULIDMatcher("@ulid@")
+	for _, p := range []string{"@ulid@(1)", `@ulid@.after("2020-01-01")`, "@ulid@.before(1)", `@ulid@.within("1 day")`, `@ulid@.within("-1h")`, "@ulid@.unknown()"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
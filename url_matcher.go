@@ -0,0 +1,195 @@
+package gomatch
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var errNotURL = errors.New("expected URL")
+
+// A URLMatcher matches absolute URLs, e.g. "https://example.com/users?page=2".
+//
+// Supported expanders:
+//
+//  @url@.scheme("https", "http")          // one of schemes
+//  @url@.host("api.example.com")          // one of hosts without port, case insensitive
+//  @url@.port(8080)
+//  @url@.path("/users/1")
+//  @url@.pathPrefix("/users/")
+//  @url@.query("page")                    // query parameter is present
+//  @url@.query("page", "2")               // query parameter has a value
+type URLMatcher struct {
+	pattern string
+}
+
+var urlExpanders = expanderSet{
+	"scheme":     urlScheme,
+	"host":       urlHost,
+	"port":       urlPort,
+	"path":       urlPath("", func(path, arg string) bool { return path == arg }),
+	"pathPrefix": urlPath(" prefix", strings.HasPrefix),
+	"query":      urlQuery,
+}
+
+// CanMatch returns true if pattern p can be handled
+func (m *URLMatcher) CanMatch(p interface{}) bool {
+	_, ok := urlExpanders.build(p, m.pattern)
+	return ok
+}
+
+// Match performs value matching against given pattern.
+func (m *URLMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+// compilePattern prepares pattern p. The URL is parsed once and checks of expanders,
+// see urlCheck, get the parsed *url.URL.
+func (m *URLMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	checks, ok := urlExpanders.build(p, m.pattern)
+	if !ok {
+		return nil, false
+	}
+	return func(v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return errNotURL
+		}
+		u, err := parseURL(s)
+		if err != nil {
+			return err
+		}
+		return runChecks(checks, u)
+	}, true
+}
+
+func (m *URLMatcher) patternExpanders() expanderSet {
+	return urlExpanders
+}
+
+// NewURLMatcher creates URLMatcher.
+func NewURLMatcher(pattern string) *URLMatcher {
+	return &URLMatcher{pattern}
+}
+
+// parseURL parses an absolute URL.
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" && u.Opaque == "" {
+		return nil, errNotURL
+	}
+	return u, nil
+}
+
+// urlCheck builds a check of a URL parsed by URLMatcher.
+func urlCheck(fn func(u *url.URL) error) func(v interface{}) error {
+	return func(v interface{}) error {
+		return fn(v.(*url.URL))
+	}
+}
+
+// stringArgs returns values of string arguments, there has to be at least one.
+func stringArgs(args []patternArg) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errInvalidArgs
+	}
+	strs := make([]string, len(args))
+	for i, a := range args {
+		if !a.isString() {
+			return nil, errNotString
+		}
+		strs[i] = a.String()
+	}
+	return strs, nil
+}
+
+func urlScheme(args []patternArg) (func(v interface{}) error, error) {
+	schemes, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return urlCheck(func(u *url.URL) error {
+		if !containsFold(schemes, u.Scheme) {
+			return fmt.Errorf("expected URL with scheme %s", strings.Join(schemes, ", "))
+		}
+		return nil
+	}), nil
+}
+
+func urlHost(args []patternArg) (func(v interface{}) error, error) {
+	hosts, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return urlCheck(func(u *url.URL) error {
+		if !containsFold(hosts, u.Hostname()) {
+			return fmt.Errorf("expected URL with host %s", strings.Join(hosts, ", "))
+		}
+		return nil
+	}), nil
+}
+
+func urlPort(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	port, err := intArg(args[0])
+	if err != nil || port > 65535 {
+		return nil, errInvalidArgs
+	}
+	return urlCheck(func(u *url.URL) error {
+		if u.Port() != strconv.Itoa(port) {
+			return fmt.Errorf("expected URL with port %d", port)
+		}
+		return nil
+	}), nil
+}
+
+func urlPath(desc string, fn func(path, arg string) bool) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 1); err != nil {
+			return nil, err
+		}
+		if !args[0].isString() {
+			return nil, errNotString
+		}
+		path := args[0].String()
+		return urlCheck(func(u *url.URL) error {
+			if !fn(u.Path, path) {
+				return fmt.Errorf("expected URL with path%s %s", desc, path)
+			}
+			return nil
+		}), nil
+	}
+}
+
+func urlQuery(args []patternArg) (func(v interface{}) error, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, errInvalidArgs
+	}
+	strs, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return urlCheck(func(u *url.URL) error {
+		values, ok := u.Query()[strs[0]]
+		if !ok {
+			return fmt.Errorf("expected URL with query parameter %s", strs[0])
+		}
+		if len(strs) == 2 && (len(values) == 0 || values[0] != strs[1]) {
+			return fmt.Errorf("expected URL with query parameter %s=%s", strs[0], strs[1])
+		}
+		return nil
+	}), nil
+}
+
+func containsFold(strs []string, s string) bool {
+	for _, e := range strs {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
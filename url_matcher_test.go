@@ -0,0 +1,59 @@
+package gomatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var urlMatcherTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match URL", "@url@", "https://example.com/users?page=2", true, ""},
+	{"Should match URL without host", "@url@", "mailto:joe@example.com", true, ""},
+	{"Should not match relative URL", "@url@", "/users?page=2", false, "expected URL"},
+	{"Should not match invalid URL", "@url@", "https://exa mple.com", false, "expected URL"},
+	{"Should not match number", "@url@", 1., false, "expected URL"},
+	{"Should match scheme", `@url@.scheme("http", "https")`, "HTTPS://example.com", true, ""},
+	{"Should not match other scheme", `@url@.scheme("https")`, "http://example.com", false, "expected URL with scheme https"},
+	{"Should match host", `@url@.host("api.example.com")`, "https://API.example.com:8080/users", true, ""},
+	{"Should not match other host", `@url@.host("api.example.com")`, "https://api.example.com.evil.io", false, "expected URL with host api.example.com"},
+	{"Should match port", `@url@.port(8080)`, "https://example.com:8080", true, ""},
+	{"Should not match other port", `@url@.port(8080)`, "https://example.com", false, "expected URL with port 8080"},
+	{"Should match path", `@url@.path("/users/1")`, "https://example.com/users/1?x=1", true, ""},
+	{"Should not match other path", `@url@.path("/users/1")`, "https://example.com/users/12", false, "expected URL with path /users/1"},
+	{"Should match path prefix", `@url@.pathPrefix("/users/")`, "https://example.com/users/12", true, ""},
+	{"Should not match other path prefix", `@url@.pathPrefix("/users/")`, "https://example.com/groups/1", false, "expected URL with path prefix /users/"},
+	{"Should match query parameter", `@url@.query("page").query("size", "10")`, "https://example.com/?page=&size=10", true, ""},
+	{"Should not match missing query parameter", `@url@.query("page")`, "https://example.com/?size=10", false, "expected URL with query parameter page"},
+	{"Should not match other query parameter value", `@url@.query("page", "2")`, "https://example.com/?page=3", false, "expected URL with query parameter page=2"},
+}
+
+func TestURLMatcher(t *testing.T) {
+	m := NewURLMatcher("@url@")
+	for _, tt := range urlMatcherTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestURLMatcherInvalidExpanders(t *testing.T) {
+	m := NewURLMatcher("@url@")
+	for _, p := range []string{"@url@.scheme()", "@url@.host(1)", "@url@.port(70000)", `@url@.port("80")`, "@url@.path()", `@url@.query("a", "b", "c")`} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}
@@ -2,36 +2,121 @@ package gomatch
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
 
 var errNotUUID = errors.New("expected UUID")
 
-// A UUIDMatcher matches booleans.
+// A UUIDMatcher matches UUIDs in any form accepted by uuid.Parse,
+// e.g. "urn:uuid:" prefixed or in braces.
+//
+// Supported expanders:
+//
+//  @uuid@.v4()          // version 4 with RFC 4122 variant
+//  @uuid@.v7()          // version 7 with RFC 4122 variant
+//  @uuid@.version(1)    // any version with RFC 4122 variant
+//  @uuid@.canonical()   // lower case, hyphenated form only
+//  @uuid@.notNil()      // not 00000000-0000-0000-0000-000000000000
 type UUIDMatcher struct {
 	pattern string
 }
 
+var uuidExpanders = expanderSet{
+	"v4":        uuidVersion(4),
+	"v7":        uuidVersion(7),
+	"version":   uuidAnyVersion,
+	"canonical": uuidCanonical,
+	"notNil":    uuidNotNil,
+}
+
 // CanMatch returns true if pattern p can be handled
 func (m *UUIDMatcher) CanMatch(p interface{}) bool {
-	return isPattern(p, m.pattern)
+	_, ok := uuidExpanders.build(p, m.pattern)
+	return ok
 }
 
 // Match performs value matching against given pattern.
 func (m *UUIDMatcher) Match(p, v interface{}) (bool, error) {
+	return matchCompiled(m.compilePattern, p, v)
+}
+
+func (m *UUIDMatcher) compilePattern(p interface{}) (func(v interface{}) error, bool) {
+	return uuidExpanders.compile(p, m.pattern, validateUUID)
+}
+
+func (m *UUIDMatcher) patternExpanders() expanderSet {
+	return uuidExpanders
+}
+
+func validateUUID(v interface{}) error {
 	s, ok := v.(string)
 	if !ok {
-		return false, errNotUUID
+		return errNotUUID
 	}
-	_, err := uuid.Parse(s)
-	if err != nil {
-		return false, errNotUUID
+	if _, err := uuid.Parse(s); err != nil {
+		return errNotUUID
 	}
-	return true, nil
+	return nil
 }
 
 // NewUUIDMatcher creates UUIDMatcher.
 func NewUUIDMatcher(pattern string) *UUIDMatcher {
 	return &UUIDMatcher{pattern}
 }
+
+func uuidVersion(version int) expanderFunc {
+	return func(args []patternArg) (func(v interface{}) error, error) {
+		if err := argsCount(args, 0); err != nil {
+			return nil, err
+		}
+		return checkUUIDVersion(version), nil
+	}
+}
+
+func uuidAnyVersion(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 1); err != nil {
+		return nil, err
+	}
+	version, err := intArg(args[0])
+	if err != nil || version < 1 || version > 15 {
+		return nil, errInvalidArgs
+	}
+	return checkUUIDVersion(version), nil
+}
+
+func checkUUIDVersion(version int) func(v interface{}) error {
+	return func(v interface{}) error {
+		u := uuid.MustParse(v.(string))
+		if int(u.Version()) != version || u.Variant() != uuid.RFC4122 {
+			return fmt.Errorf("expected UUID version %d", version)
+		}
+		return nil
+	}
+}
+
+func uuidCanonical(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		s := v.(string)
+		if canonical := uuid.MustParse(s).String(); canonical != s {
+			return fmt.Errorf("expected UUID in canonical form %s", canonical)
+		}
+		return nil
+	}, nil
+}
+
+func uuidNotNil(args []patternArg) (func(v interface{}) error, error) {
+	if err := argsCount(args, 0); err != nil {
+		return nil, err
+	}
+	return func(v interface{}) error {
+		if uuid.MustParse(v.(string)) == uuid.Nil {
+			return errors.New("expected not nil UUID")
+		}
+		return nil
+	}, nil
+}
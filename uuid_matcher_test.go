@@ -52,3 +52,48 @@ func TestUUIDMatcher(t *testing.T) {
 		}
 	}
 }
+
+var uuidExpanderTests = []struct {
+	desc   string
+	p      string
+	v      interface{}
+	ok     bool
+	errMsg string
+}{
+	{"Should match UUID v4", "@uuid@.v4()", "f47ac10b-58cc-4372-a567-0e02b2c3d479", true, ""},
+	{"Should not match UUID v1 as v4", "@uuid@.v4()", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", false, "expected UUID version 4"},
+	{"Should match UUID v7", "@uuid@.v7()", "01890a5d-ac96-774b-bcce-b302099a8057", true, ""},
+	{"Should not match UUID v7 of other variant", "@uuid@.v7()", "01890a5d-ac96-774b-ccce-b302099a8057", false, "expected UUID version 7"},
+	{"Should match any UUID version", "@uuid@.version(1)", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true, ""},
+	{"Should match canonical UUID", "@uuid@.canonical()", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true, ""},
+	{"Should not match upper case UUID as canonical", "@uuid@.canonical()", "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", false, "expected UUID in canonical form 6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	{"Should not match URN as canonical", "@uuid@.canonical()", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", false, "expected UUID in canonical form 6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	{"Should match not nil UUID", "@uuid@.notNil()", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true, ""},
+	{"Should not match nil UUID", "@uuid@.notNil().canonical()", "00000000-0000-0000-0000-000000000000", false, "expected not nil UUID"},
+	{"Should check UUID before expanders", "@uuid@.v4()", "x", false, "expected UUID"},
+}
+
+func TestUUIDMatcherExpanders(t *testing.T) {
+	m := NewUUIDMatcher("@uuid@")
+	for _, tt := range uuidExpanderTests {
+		t.Logf(tt.desc)
+		assert.True(t, m.CanMatch(tt.p), "expected to support pattern")
+
+		ok, err := m.Match(tt.p, tt.v)
+
+		if tt.ok {
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		} else {
+			assert.False(t, ok)
+			assert.EqualError(t, err, tt.errMsg)
+		}
+	}
+}
+
+func TestUUIDMatcherInvalidExpanders(t *testing.T) {
+	m := NewUUIDMatcher("@uuid@")
+	for _, p := range []string{"@uuid@.v4(1)", "@uuid@.version()", "@uuid@.version(0)", "@uuid@.version(16)", "@uuid@.v5()"} {
+		assert.False(t, m.CanMatch(p), "not expected to support %s", p)
+	}
+}